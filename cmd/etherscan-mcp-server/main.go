@@ -7,9 +7,11 @@ import (
 	"log"
 	"os"
 	"os/signal"
+	"strings"
 	"syscall"
 	"time"
 
+	"github.com/huahuayu/etherscan-mcp-server/internal/chains"
 	"github.com/huahuayu/etherscan-mcp-server/internal/etherscan"
 	"github.com/huahuayu/etherscan-mcp-server/internal/mcp"
 	"github.com/huahuayu/etherscan-mcp-server/internal/rpc"
@@ -30,9 +32,6 @@ func main() {
 
 	// Get environment variables with defaults
 	apiKey := getEnv("ETHERSCAN_API_KEY", "")
-	if apiKey == "" {
-		log.Fatal("ETHERSCAN_API_KEY environment variable is required")
-	}
 
 	// Check env var for SSE mode (overrides flag if set)
 	useSSEEnv := getEnv("USE_SSE", "")
@@ -57,20 +56,86 @@ func main() {
 		log.SetFlags(log.Ldate | log.Ltime)
 	}
 
-	// Initialize Etherscan client
+	// Extend or override the built-in chain registry
+	if chainsFile := getEnv("CHAINS_FILE", ""); chainsFile != "" {
+		if err := chains.LoadFile(chainsFile); err != nil {
+			log.Fatalf("Invalid CHAINS_FILE: %v", err)
+		}
+	}
+
+	// Initialize Etherscan client, and route chains to other explorers where configured
+	// (format: chain=kind[:endpoint],chain=kind[:endpoint])
 	client := etherscan.NewClient(apiKey)
+	explorer := etherscan.NewRouter(client)
+	explorerAPIs := getEnv("EXPLORER_APIS", "")
+	if explorerAPIs != "" {
+		configs, err := etherscan.ParseExplorers(explorerAPIs)
+		if err != nil {
+			log.Fatalf("Invalid EXPLORER_APIS: %v", err)
+		}
+		for chainID, config := range configs {
+			chainExplorer, err := etherscan.NewExplorer(config, client, getEnv(strings.ToUpper(config.Kind)+"_API_KEY", ""))
+			if err != nil {
+				log.Fatalf("Invalid EXPLORER_APIS entry for chain %s: %v", chainID, err)
+			}
+			explorer.SetExplorer(chainID, chainExplorer)
+		}
+	}
+
+	// Register additional RPC endpoints (format: chainID=url,chainID=url)
+	if rpcURLs := getEnv("RPC_URLS", ""); rpcURLs != "" {
+		endpoints, err := rpc.ParseEndpoints(rpcURLs)
+		if err != nil {
+			log.Fatalf("Invalid RPC_URLS: %v", err)
+		}
+		for chainID, rpcURL := range endpoints {
+			rpc.SetEndpoint(chainID, rpcURL)
+		}
+	}
 
 	// Initialize RPC client for fallback
 	rpcClient := rpc.NewClient()
 
+	// Without an API key or another explorer, serve what JSON-RPC can and leave the explorer-only tools out
+	if apiKey == "" && explorerAPIs == "" {
+		log.Printf("ETHERSCAN_API_KEY is not set: running in RPC-only mode")
+		mcp.EnableRPCOnlyMode()
+	} else if apiKey == "" {
+		log.Printf("ETHERSCAN_API_KEY is not set: only the chains in EXPLORER_APIS can use an explorer")
+	}
+
+	// Choose which backend answers the tools both Etherscan and RPC can serve
+	if strategy := getEnv("BACKEND_STRATEGY", ""); strategy != "" {
+		if err := mcp.SetBackendStrategy("", strategy); err != nil {
+			log.Fatalf("Invalid BACKEND_STRATEGY: %v", err)
+		}
+	}
+	if strategies := getEnv("BACKEND_STRATEGIES", ""); strategies != "" {
+		if err := mcp.ConfigureBackendStrategies(strategies); err != nil {
+			log.Fatalf("Invalid BACKEND_STRATEGIES: %v", err)
+		}
+	}
+
 	// Create MCP server
 	mcpServer := server.NewMCPServer(
 		"Etherscan MCP Server",
 		"1.0.0",
 	)
 
+	// Response normalization is on unless explicitly disabled
+	if getEnv("NORMALIZE_RESPONSES", "") == "false" {
+		mcp.SetNormalization(false)
+	}
+
 	// Register tools
-	mcp.RegisterTools(mcpServer, client, rpcClient)
+	mcp.RegisterTools(mcpServer, explorer, rpcClient)
+	if getEnv("ENABLE_WRITE_TOOLS", "") == "true" {
+		log.Printf("Write tools enabled: signed transactions can be broadcast")
+		mcp.RegisterWriteTools(mcpServer, explorer, rpcClient)
+	}
+	if disabled := mcp.DisabledTools(); len(disabled) > 0 {
+		log.Printf("RPC-only mode: %d tools need an Etherscan API key and are unavailable: %s", len(disabled), strings.Join(disabled, ", "))
+	}
 
 	if *useSSE {
 		// SSE server mode
@@ -7,9 +7,11 @@ toolchain go1.23.4
 require (
 	github.com/joho/godotenv v1.5.1
 	github.com/mark3labs/mcp-go v0.18.0
+	golang.org/x/crypto v0.31.0
 )
 
 require (
 	github.com/google/uuid v1.6.0 // indirect
 	github.com/yosida95/uritemplate/v3 v3.0.2 // indirect
+	golang.org/x/sys v0.28.0 // indirect
 )
@@ -0,0 +1,283 @@
+// Package abi parses contract ABIs and decodes call data and return values.
+package abi
+
+import (
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/huahuayu/etherscan-mcp-server/internal/evm"
+)
+
+// Argument is a function input or output as described in an ABI JSON entry
+type Argument struct {
+	Name       string     `json:"name"`
+	Type       string     `json:"type"`
+	Indexed    bool       `json:"indexed,omitempty"`
+	Components []Argument `json:"components,omitempty"`
+}
+
+// Method is a contract function with its 4-byte selector
+type Method struct {
+	Name      string
+	Signature string
+	Selector  string
+	Inputs    []Argument
+	Outputs   []Argument
+}
+
+// ABI is a parsed contract ABI indexed by method and custom error selector and by event topic
+type ABI struct {
+	Methods map[string]*Method
+	Errors  map[string]*Method
+	Events  map[string][]*Event
+}
+
+// abiEntry is a single entry of an ABI JSON array
+type abiEntry struct {
+	Type      string     `json:"type"`
+	Name      string     `json:"name"`
+	Inputs    []Argument `json:"inputs"`
+	Outputs   []Argument `json:"outputs"`
+	Anonymous bool       `json:"anonymous"`
+}
+
+// DecodedArgument is a decoded value together with its ABI name and type
+type DecodedArgument struct {
+	Name  string      `json:"name,omitempty"`
+	Type  string      `json:"type"`
+	Value interface{} `json:"value"`
+}
+
+// Parse parses an ABI JSON array
+func Parse(abiJSON string) (*ABI, error) {
+	var entries []abiEntry
+	if err := json.Unmarshal([]byte(abiJSON), &entries); err != nil {
+		return nil, fmt.Errorf("failed to parse ABI: %w", err)
+	}
+
+	parsed := newABI()
+	for _, entry := range entries {
+		switch entry.Type {
+		case "function", "":
+			method := newMethod(entry.Name, entry.Inputs, entry.Outputs)
+			parsed.Methods[method.Selector] = method
+		case "error":
+			customError := newMethod(entry.Name, entry.Inputs, nil)
+			parsed.Errors[customError.Selector] = customError
+		case "event":
+			// Anonymous events have no signature topic to match logs against
+			if !entry.Anonymous {
+				parsed.addEvent(newEvent(entry.Name, entry.Inputs))
+			}
+		}
+	}
+	return parsed, nil
+}
+
+// Merge adds the entries of other that are not already present
+func (a *ABI) Merge(other *ABI) {
+	for selector, method := range other.Methods {
+		if _, ok := a.Methods[selector]; !ok {
+			a.Methods[selector] = method
+		}
+	}
+	for selector, customError := range other.Errors {
+		if _, ok := a.Errors[selector]; !ok {
+			a.Errors[selector] = customError
+		}
+	}
+	for _, events := range other.Events {
+		for _, event := range events {
+			a.addEvent(event)
+		}
+	}
+}
+
+func newABI() *ABI {
+	return &ABI{
+		Methods: make(map[string]*Method),
+		Errors:  make(map[string]*Method),
+		Events:  make(map[string][]*Event),
+	}
+}
+
+// MethodByData finds the method called by the given call data
+func (a *ABI) MethodByData(data []byte) (*Method, bool) {
+	if len(data) < 4 {
+		return nil, false
+	}
+	method, ok := a.Methods[Selector(data)]
+	return method, ok
+}
+
+// Selector returns the 0x-prefixed 4-byte selector of call data
+func Selector(data []byte) string {
+	if len(data) < 4 {
+		return ""
+	}
+	return "0x" + hex.EncodeToString(data[:4])
+}
+
+// ParseSignature parses a human-readable function signature such as
+// "transfer(address to,uint256 amount)". Argument names are optional.
+func ParseSignature(signature string) (*Method, error) {
+	name, inputs, err := parseSignature(signature)
+	if err != nil {
+		return nil, err
+	}
+	return newMethod(name, inputs, nil), nil
+}
+
+// parseSignature splits a human-readable signature into its name and arguments
+func parseSignature(signature string) (string, []Argument, error) {
+	open := strings.Index(signature, "(")
+	if open <= 0 || !strings.HasSuffix(signature, ")") {
+		return "", nil, fmt.Errorf("invalid signature: %s", signature)
+	}
+
+	arguments, err := parseArgumentList(signature[open+1 : len(signature)-1])
+	if err != nil {
+		return "", nil, fmt.Errorf("invalid signature %s: %w", signature, err)
+	}
+
+	return strings.TrimSpace(signature[:open]), arguments, nil
+}
+
+// DecodeInput decodes the arguments of call data for this method
+func (m *Method) DecodeInput(data []byte) ([]DecodedArgument, error) {
+	if len(data) < 4 {
+		return nil, fmt.Errorf("call data too short")
+	}
+	return DecodeArguments(m.Inputs, data[4:])
+}
+
+// DecodeOutput decodes the return data of this method
+func (m *Method) DecodeOutput(data []byte) ([]DecodedArgument, error) {
+	return DecodeArguments(m.Outputs, data)
+}
+
+// DecodeArguments decodes ABI-encoded data as a tuple of the given arguments
+func DecodeArguments(arguments []Argument, data []byte) ([]DecodedArgument, error) {
+	types := make([]*abiType, len(arguments))
+	for i, argument := range arguments {
+		t, err := parseType(argument.Type, argument.Components)
+		if err != nil {
+			return nil, err
+		}
+		types[i] = t
+	}
+
+	values, err := decodeTuple(types, data)
+	if err != nil {
+		return nil, err
+	}
+
+	decoded := make([]DecodedArgument, len(arguments))
+	for i, argument := range arguments {
+		decoded[i] = DecodedArgument{Name: argument.Name, Type: types[i].String(), Value: values[i]}
+	}
+	return decoded, nil
+}
+
+func newMethod(name string, inputs, outputs []Argument) *Method {
+	signature := name + "(" + joinTypes(inputs) + ")"
+	return &Method{
+		Name:      name,
+		Signature: signature,
+		Selector:  "0x" + hex.EncodeToString(evm.Keccak256([]byte(signature))[:4]),
+		Inputs:    inputs,
+		Outputs:   outputs,
+	}
+}
+
+// joinTypes returns the canonical comma-separated types of arguments, expanding tuples
+func joinTypes(arguments []Argument) string {
+	types := make([]string, len(arguments))
+	for i, argument := range arguments {
+		types[i] = canonicalType(argument)
+	}
+	return strings.Join(types, ",")
+}
+
+func canonicalType(argument Argument) string {
+	if strings.HasPrefix(argument.Type, "tuple") {
+		return "(" + joinTypes(argument.Components) + ")" + strings.TrimPrefix(argument.Type, "tuple")
+	}
+	switch argument.Type {
+	case "uint":
+		return "uint256"
+	case "int":
+		return "int256"
+	}
+	return argument.Type
+}
+
+// parseArgumentList parses the comma-separated arguments of a human-readable signature
+func parseArgumentList(list string) ([]Argument, error) {
+	var arguments []Argument
+	for _, part := range splitTopLevel(list) {
+		part = strings.TrimSpace(part)
+		if part == "" {
+			continue
+		}
+
+		argument := Argument{}
+		if strings.HasPrefix(part, "(") {
+			close := strings.LastIndex(part, ")")
+			if close < 0 {
+				return nil, fmt.Errorf("unbalanced parentheses in %s", part)
+			}
+			components, err := parseArgumentList(part[1:close])
+			if err != nil {
+				return nil, err
+			}
+			rest := strings.Fields(part[close+1:])
+			argument.Type = "tuple"
+			argument.Components = components
+			if len(rest) > 0 && strings.HasPrefix(rest[0], "[") {
+				argument.Type += rest[0]
+				rest = rest[1:]
+			}
+			for _, keyword := range rest {
+				argument.Indexed = argument.Indexed || keyword == "indexed"
+			}
+			if len(rest) > 0 && rest[len(rest)-1] != "indexed" {
+				argument.Name = rest[len(rest)-1]
+			}
+		} else {
+			fields := strings.Fields(part)
+			argument.Type = fields[0]
+			for _, keyword := range fields[1:] {
+				argument.Indexed = argument.Indexed || keyword == "indexed"
+			}
+			if len(fields) > 1 && fields[len(fields)-1] != "indexed" {
+				// Skip keywords such as "indexed", "calldata" or "memory"
+				argument.Name = fields[len(fields)-1]
+			}
+		}
+		arguments = append(arguments, argument)
+	}
+	return arguments, nil
+}
+
+// splitTopLevel splits on commas that are not nested inside parentheses
+func splitTopLevel(list string) []string {
+	var parts []string
+	depth, start := 0, 0
+	for i, r := range list {
+		switch r {
+		case '(':
+			depth++
+		case ')':
+			depth--
+		case ',':
+			if depth == 0 {
+				parts = append(parts, list[start:i])
+				start = i + 1
+			}
+		}
+	}
+	return append(parts, list[start:])
+}
@@ -0,0 +1,305 @@
+package abi
+
+import (
+	"encoding/hex"
+	"encoding/json"
+	"strings"
+	"testing"
+)
+
+// word left-pads a hex value to a 32-byte ABI word
+func word(value string) string {
+	return strings.Repeat("0", 64-len(value)) + value
+}
+
+// padRight right-pads hex bytes to a multiple of 32 bytes, as ABI encodes bytes and strings
+func padRight(value string) string {
+	if rest := len(value) % 64; rest != 0 {
+		value += strings.Repeat("0", 64-rest)
+	}
+	return value
+}
+
+func mustHex(t *testing.T, value string) []byte {
+	t.Helper()
+	data, err := hex.DecodeString(value)
+	if err != nil {
+		t.Fatalf("invalid test hex %q: %v", value, err)
+	}
+	return data
+}
+
+// valuesJSON renders decoded values as JSON so that nested lists and maps compare easily
+func valuesJSON(t *testing.T, decoded []DecodedArgument) string {
+	t.Helper()
+	values := make([]interface{}, len(decoded))
+	for i, argument := range decoded {
+		values[i] = argument.Value
+	}
+	encoded, err := json.Marshal(values)
+	if err != nil {
+		t.Fatalf("failed to encode values: %v", err)
+	}
+	return string(encoded)
+}
+
+func TestParseSignature(t *testing.T) {
+	tests := []struct {
+		signature     string
+		wantSignature string
+		wantSelector  string
+	}{
+		{"transfer(address,uint256)", "transfer(address,uint256)", "0xa9059cbb"},
+		{"transfer(address to, uint amount)", "transfer(address,uint256)", "0xa9059cbb"},
+		{"balanceOf(address)", "balanceOf(address)", "0x70a08231"},
+		{"approve(address spender,uint256 amount)", "approve(address,uint256)", "0x095ea7b3"},
+		{"multicall(bytes[] calldata data)", "multicall(bytes[])", "0xac9650d8"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.signature, func(t *testing.T) {
+			method, err := ParseSignature(tt.signature)
+			if err != nil {
+				t.Fatalf("ParseSignature() error = %v", err)
+			}
+			if method.Signature != tt.wantSignature || method.Selector != tt.wantSelector {
+				t.Errorf("got %s %s, want %s %s", method.Signature, method.Selector, tt.wantSignature, tt.wantSelector)
+			}
+		})
+	}
+}
+
+func TestDecodeArguments(t *testing.T) {
+	address := "00000000000000000000000000000000deadbeef"
+
+	tests := []struct {
+		name      string
+		arguments []Argument
+		data      string
+		want      string
+		wantErr   string
+	}{
+		{
+			name:      "static values",
+			arguments: []Argument{{Type: "address"}, {Type: "uint256"}, {Type: "bool"}},
+			data:      word(address) + word("de0b6b3a7640000") + word("1"),
+			want:      `["0x00000000000000000000000000000000deadbeef","1000000000000000000",true]`,
+		},
+		{
+			name:      "negative int",
+			arguments: []Argument{{Type: "int8"}},
+			data:      strings.Repeat("f", 64),
+			want:      `["-1"]`,
+		},
+		{
+			name:      "fixed bytes",
+			arguments: []Argument{{Type: "bytes4"}},
+			data:      padRight("a9059cbb"),
+			want:      `["0xa9059cbb"]`,
+		},
+		{
+			name:      "string and dynamic array",
+			arguments: []Argument{{Type: "string"}, {Type: "uint256[]"}},
+			data: word("40") + word("80") +
+				word("5") + padRight(hex.EncodeToString([]byte("hello"))) +
+				word("2") + word("1") + word("2"),
+			want: `["hello",["1","2"]]`,
+		},
+		{
+			name:      "fixed array",
+			arguments: []Argument{{Type: "uint8[2]"}, {Type: "bool"}},
+			data:      word("7") + word("9") + word("0"),
+			want:      `[["7","9"],false]`,
+		},
+		{
+			name: "named tuple",
+			arguments: []Argument{{Type: "tuple", Components: []Argument{
+				{Name: "amount", Type: "uint256"},
+				{Name: "recipient", Type: "address"},
+			}}},
+			data: word("2a") + word(address),
+			want: `[{"amount":"42","recipient":"0x00000000000000000000000000000000deadbeef"}]`,
+		},
+		{
+			name: "dynamic tuple",
+			arguments: []Argument{{Type: "tuple", Components: []Argument{
+				{Type: "uint256"},
+				{Type: "bytes"},
+			}}},
+			data: word("20") + word("3") + word("40") + word("2") + padRight("cafe"),
+			want: `[["3","0xcafe"]]`,
+		},
+		{
+			name:      "truncated static value",
+			arguments: []Argument{{Type: "address"}, {Type: "uint256"}},
+			data:      word(address),
+			wantErr:   "data too short",
+		},
+		{
+			name:      "offset out of bounds",
+			arguments: []Argument{{Type: "string"}},
+			data:      word("1000"),
+			wantErr:   "out of bounds",
+		},
+		{
+			name:      "string longer than data",
+			arguments: []Argument{{Type: "string"}},
+			data:      word("20") + word("40") + padRight("61"),
+			wantErr:   "exceeds data",
+		},
+		{
+			name:      "oversized length",
+			arguments: []Argument{{Type: "uint256[]"}},
+			data:      word("20") + "8" + strings.Repeat("0", 63),
+			wantErr:   "too large",
+		},
+		{
+			name:      "unsupported type",
+			arguments: []Argument{{Type: "uint7"}},
+			data:      word("1"),
+			wantErr:   "invalid type",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			decoded, err := DecodeArguments(tt.arguments, mustHex(t, tt.data))
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("DecodeArguments() error = %v, want %q", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("DecodeArguments() error = %v", err)
+			}
+			if got := valuesJSON(t, decoded); got != tt.want {
+				t.Errorf("got %s, want %s", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestMethodByData(t *testing.T) {
+	parsed, err := Parse(`[
+		{"type":"function","name":"transfer","inputs":[{"name":"to","type":"address"},{"name":"amount","type":"uint256"}],"outputs":[{"name":"","type":"bool"}]},
+		{"type":"error","name":"InsufficientBalance","inputs":[{"name":"available","type":"uint256"}]},
+		{"type":"event","name":"Transfer","inputs":[{"name":"from","type":"address","indexed":true},{"name":"to","type":"address","indexed":true},{"name":"value","type":"uint256","indexed":false}]}
+	]`)
+	if err != nil {
+		t.Fatalf("Parse() error = %v", err)
+	}
+
+	data := mustHex(t, "a9059cbb"+word("00000000000000000000000000000000deadbeef")+word("64"))
+	method, ok := parsed.MethodByData(data)
+	if !ok {
+		t.Fatalf("MethodByData() found no method for selector %s", Selector(data))
+	}
+	decoded, err := method.DecodeInput(data)
+	if err != nil {
+		t.Fatalf("DecodeInput() error = %v", err)
+	}
+	if decoded[0].Name != "to" || decoded[1].Name != "amount" || decoded[1].Value != "100" {
+		t.Errorf("got %+v, want to and amount 100", decoded)
+	}
+
+	if _, ok := parsed.MethodByData(mustHex(t, "deadbeef")); ok {
+		t.Errorf("MethodByData() matched an unknown selector")
+	}
+	if _, ok := parsed.MethodByData(mustHex(t, "a905")); ok {
+		t.Errorf("MethodByData() matched data shorter than a selector")
+	}
+}
+
+func TestDecodeLog(t *testing.T) {
+	from := word("00000000000000000000000000000000000000aa")
+	to := word("00000000000000000000000000000000000000bb")
+	transferTopic := "0xddf252ad1be2c89b69c2b068fc378daa952ba7f163c4a11628f55a4df523b3ef"
+
+	tests := []struct {
+		name    string
+		topics  []string
+		data    string
+		want    string
+		wantErr string
+	}{
+		{
+			name:   "erc20 transfer",
+			topics: []string{transferTopic, "0x" + from, "0x" + to},
+			data:   word("3e8"),
+			want:   `["0x00000000000000000000000000000000000000aa","0x00000000000000000000000000000000000000bb","1000"]`,
+		},
+		{
+			name:   "erc721 transfer",
+			topics: []string{transferTopic, "0x" + from, "0x" + to, "0x" + word("7")},
+			want:   `["0x00000000000000000000000000000000000000aa","0x00000000000000000000000000000000000000bb","7"]`,
+		},
+		{
+			name:    "invalid topic",
+			topics:  []string{transferTopic, "0x" + from, "0x1234"},
+			data:    word("1"),
+			wantErr: "invalid topic",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			event, ok := Common.EventByTopics(tt.topics)
+			if !ok {
+				t.Fatalf("EventByTopics() found no event for %d topics", len(tt.topics))
+			}
+			decoded, err := event.DecodeLog(tt.topics, mustHex(t, tt.data))
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("DecodeLog() error = %v, want %q", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("DecodeLog() error = %v", err)
+			}
+			if got := valuesJSON(t, decoded); got != tt.want {
+				t.Errorf("got %s, want %s", got, tt.want)
+			}
+		})
+	}
+
+	if _, ok := Common.EventByTopics([]string{transferTopic}); ok {
+		t.Errorf("EventByTopics() matched a Transfer log without indexed topics")
+	}
+}
+
+func TestDecodeRevert(t *testing.T) {
+	custom, err := Parse(`[{"type":"error","name":"InsufficientBalance","inputs":[{"name":"available","type":"uint256"}]}]`)
+	if err != nil {
+		t.Fatalf("Parse() error = %v", err)
+	}
+	var customSelector string
+	for selector := range custom.Errors {
+		customSelector = selector
+	}
+
+	tests := []struct {
+		name       string
+		data       string
+		wantKind   string
+		wantReason string
+	}{
+		{"empty", "", "empty", "reverted without a reason"},
+		{"error string", "08c379a0" + word("20") + word("4") + padRight(hex.EncodeToString([]byte("nope"))), "error", "nope"},
+		{"panic with known code", "4e487b71" + word("11"), "panic", "panic 0x11: arithmetic overflow or underflow"},
+		{"panic with unknown code", "4e487b71" + word("99"), "panic", "panic 0x99"},
+		{"custom error", strings.TrimPrefix(customSelector, "0x") + word("5"), "custom", "InsufficientBalance"},
+		{"unknown selector", "deadbeef", "unknown", ""},
+		{"short data", "dead", "unknown", ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			revert := DecodeRevert(mustHex(t, tt.data), custom)
+			if revert.Kind != tt.wantKind || revert.Reason != tt.wantReason {
+				t.Errorf("got %s %q, want %s %q", revert.Kind, revert.Reason, tt.wantKind, tt.wantReason)
+			}
+		})
+	}
+}
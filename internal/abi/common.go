@@ -0,0 +1,77 @@
+package abi
+
+// commonSignatures are widely used functions decoded even when the contract isn't verified
+var commonSignatures = []string{
+	// ERC20
+	"transfer(address to,uint256 amount)",
+	"approve(address spender,uint256 amount)",
+	"transferFrom(address from,address to,uint256 amount)",
+	"increaseAllowance(address spender,uint256 addedValue)",
+	"decreaseAllowance(address spender,uint256 subtractedValue)",
+	"permit(address owner,address spender,uint256 value,uint256 deadline,uint8 v,bytes32 r,bytes32 s)",
+	// ERC721 / ERC1155
+	"safeTransferFrom(address from,address to,uint256 tokenId)",
+	"safeTransferFrom(address from,address to,uint256 tokenId,bytes data)",
+	"safeTransferFrom(address from,address to,uint256 id,uint256 amount,bytes data)",
+	"safeBatchTransferFrom(address from,address to,uint256[] ids,uint256[] amounts,bytes data)",
+	"setApprovalForAll(address operator,bool approved)",
+	// Wrapped native tokens
+	"deposit()",
+	"withdraw(uint256 amount)",
+	// Multicall
+	"multicall(bytes[] data)",
+	"multicall(uint256 deadline,bytes[] data)",
+	"aggregate((address target,bytes callData)[] calls)",
+	"aggregate3((address target,bool allowFailure,bytes callData)[] calls)",
+	// Uniswap
+	"execute(bytes commands,bytes[] inputs)",
+	"execute(bytes commands,bytes[] inputs,uint256 deadline)",
+	"swapExactTokensForTokens(uint256 amountIn,uint256 amountOutMin,address[] path,address to,uint256 deadline)",
+	"swapExactETHForTokens(uint256 amountOutMin,address[] path,address to,uint256 deadline)",
+	"swapExactTokensForETH(uint256 amountIn,uint256 amountOutMin,address[] path,address to,uint256 deadline)",
+}
+
+// commonEventSignatures are widely used events decoded even when the contract isn't verified
+var commonEventSignatures = []string{
+	// ERC20 / ERC721 (same topic, told apart by indexed arguments)
+	"Transfer(address indexed from,address indexed to,uint256 value)",
+	"Transfer(address indexed from,address indexed to,uint256 indexed tokenId)",
+	"Approval(address indexed owner,address indexed spender,uint256 value)",
+	"Approval(address indexed owner,address indexed approved,uint256 indexed tokenId)",
+	"ApprovalForAll(address indexed owner,address indexed operator,bool approved)",
+	// ERC1155
+	"TransferSingle(address indexed operator,address indexed from,address indexed to,uint256 id,uint256 value)",
+	"TransferBatch(address indexed operator,address indexed from,address indexed to,uint256[] ids,uint256[] values)",
+	// Wrapped native tokens
+	"Deposit(address indexed dst,uint256 wad)",
+	"Withdrawal(address indexed src,uint256 wad)",
+	// Uniswap V2 / V3 pools
+	"Swap(address indexed sender,uint256 amount0In,uint256 amount1In,uint256 amount0Out,uint256 amount1Out,address indexed to)",
+	"Swap(address indexed sender,address indexed recipient,int256 amount0,int256 amount1,uint160 sqrtPriceX96,uint128 liquidity,int24 tick)",
+	"Sync(uint112 reserve0,uint112 reserve1)",
+	// Ownership and upgrades
+	"OwnershipTransferred(address indexed previousOwner,address indexed newOwner)",
+	"Upgraded(address indexed implementation)",
+}
+
+// Common is an ABI of widely used functions and events
+var Common = mustParseSignatures(commonSignatures, commonEventSignatures)
+
+func mustParseSignatures(methodSignatures, eventSignatures []string) *ABI {
+	parsed := newABI()
+	for _, signature := range methodSignatures {
+		method, err := ParseSignature(signature)
+		if err != nil {
+			panic(err)
+		}
+		parsed.Methods[method.Selector] = method
+	}
+	for _, signature := range eventSignatures {
+		event, err := ParseEventSignature(signature)
+		if err != nil {
+			panic(err)
+		}
+		parsed.addEvent(event)
+	}
+	return parsed
+}
@@ -0,0 +1,280 @@
+package abi
+
+import (
+	"encoding/hex"
+	"fmt"
+	"math/big"
+	"strconv"
+	"strings"
+)
+
+// typeKind is the category of a Solidity ABI type
+type typeKind int
+
+const (
+	kindUint typeKind = iota
+	kindInt
+	kindAddress
+	kindBool
+	kindFixedBytes
+	kindBytes
+	kindString
+	kindSlice
+	kindArray
+	kindTuple
+)
+
+// abiType is a parsed ABI type
+type abiType struct {
+	kind       typeKind
+	size       int // bits for integers, bytes for fixed bytes
+	length     int // length of fixed arrays
+	elem       *abiType
+	components []*abiType
+	names      []string
+	raw        string
+}
+
+// maxDecodedLength bounds array and byte lengths read from untrusted data
+const maxDecodedLength = 1 << 20
+
+func (t *abiType) String() string {
+	return t.raw
+}
+
+// parseType parses an ABI type string, using components for tuples
+func parseType(typ string, components []Argument) (*abiType, error) {
+	t := &abiType{raw: typ}
+
+	// Array suffixes apply to everything before them, e.g. uint256[2][]
+	if strings.HasSuffix(typ, "]") {
+		open := strings.LastIndex(typ, "[")
+		if open < 0 {
+			return nil, fmt.Errorf("invalid type: %s", typ)
+		}
+		elem, err := parseType(typ[:open], components)
+		if err != nil {
+			return nil, err
+		}
+		t.elem = elem
+		if open+1 == len(typ)-1 {
+			t.kind = kindSlice
+			return t, nil
+		}
+		length, err := strconv.Atoi(typ[open+1 : len(typ)-1])
+		if err != nil || length <= 0 {
+			return nil, fmt.Errorf("invalid array length in type: %s", typ)
+		}
+		t.kind = kindArray
+		t.length = length
+		return t, nil
+	}
+
+	switch {
+	case typ == "tuple":
+		t.kind = kindTuple
+		for _, component := range components {
+			ct, err := parseType(component.Type, component.Components)
+			if err != nil {
+				return nil, err
+			}
+			t.components = append(t.components, ct)
+			t.names = append(t.names, component.Name)
+		}
+	case typ == "address":
+		t.kind = kindAddress
+	case typ == "bool":
+		t.kind = kindBool
+	case typ == "string":
+		t.kind = kindString
+	case typ == "bytes":
+		t.kind = kindBytes
+	case typ == "function":
+		t.kind = kindFixedBytes
+		t.size = 24
+	case strings.HasPrefix(typ, "bytes"):
+		size, err := strconv.Atoi(strings.TrimPrefix(typ, "bytes"))
+		if err != nil || size < 1 || size > 32 {
+			return nil, fmt.Errorf("invalid type: %s", typ)
+		}
+		t.kind = kindFixedBytes
+		t.size = size
+	case strings.HasPrefix(typ, "uint"), strings.HasPrefix(typ, "int"):
+		t.kind = kindUint
+		bits := strings.TrimPrefix(typ, "uint")
+		if strings.HasPrefix(typ, "int") {
+			t.kind = kindInt
+			bits = strings.TrimPrefix(typ, "int")
+		}
+		t.size = 256
+		if bits != "" {
+			size, err := strconv.Atoi(bits)
+			if err != nil || size < 8 || size > 256 || size%8 != 0 {
+				return nil, fmt.Errorf("invalid type: %s", typ)
+			}
+			t.size = size
+		}
+	default:
+		return nil, fmt.Errorf("unsupported type: %s", typ)
+	}
+	return t, nil
+}
+
+// dynamic reports whether a type is encoded in the tail of its enclosing tuple
+func (t *abiType) dynamic() bool {
+	switch t.kind {
+	case kindBytes, kindString, kindSlice:
+		return true
+	case kindArray:
+		return t.elem.dynamic()
+	case kindTuple:
+		for _, component := range t.components {
+			if component.dynamic() {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// headSize is the number of bytes a type occupies in the head of its enclosing tuple
+func (t *abiType) headSize() int {
+	if t.dynamic() {
+		return 32
+	}
+	switch t.kind {
+	case kindArray:
+		return t.length * t.elem.headSize()
+	case kindTuple:
+		size := 0
+		for _, component := range t.components {
+			size += component.headSize()
+		}
+		return size
+	}
+	return 32
+}
+
+// decodeTuple decodes a sequence of values using the head/tail layout
+func decodeTuple(types []*abiType, data []byte) ([]interface{}, error) {
+	values := make([]interface{}, len(types))
+	offset := 0
+	for i, t := range types {
+		if t.dynamic() {
+			pointer, err := readLength(data, offset)
+			if err != nil {
+				return nil, err
+			}
+			if pointer > len(data) {
+				return nil, fmt.Errorf("offset %d out of bounds", pointer)
+			}
+			if values[i], err = decodeValue(t, data[pointer:]); err != nil {
+				return nil, err
+			}
+			offset += 32
+			continue
+		}
+
+		if offset+t.headSize() > len(data) {
+			return nil, fmt.Errorf("data too short for %s", t)
+		}
+		value, err := decodeValue(t, data[offset:])
+		if err != nil {
+			return nil, err
+		}
+		values[i] = value
+		offset += t.headSize()
+	}
+	return values, nil
+}
+
+// decodeValue decodes a single value whose encoding starts at the beginning of data
+func decodeValue(t *abiType, data []byte) (interface{}, error) {
+	switch t.kind {
+	case kindSlice:
+		length, err := readLength(data, 0)
+		if err != nil {
+			return nil, err
+		}
+		return decodeTuple(repeat(t.elem, length), data[32:])
+
+	case kindArray:
+		return decodeTuple(repeat(t.elem, t.length), data)
+
+	case kindTuple:
+		values, err := decodeTuple(t.components, data)
+		if err != nil {
+			return nil, err
+		}
+		return namedTuple(t.names, values), nil
+
+	case kindBytes, kindString:
+		length, err := readLength(data, 0)
+		if err != nil {
+			return nil, err
+		}
+		if 32+length > len(data) {
+			return nil, fmt.Errorf("%s exceeds data", t)
+		}
+		content := data[32 : 32+length]
+		if t.kind == kindString {
+			return string(content), nil
+		}
+		return "0x" + hex.EncodeToString(content), nil
+	}
+
+	if len(data) < 32 {
+		return nil, fmt.Errorf("data too short for %s", t)
+	}
+	word := data[:32]
+
+	switch t.kind {
+	case kindUint:
+		return new(big.Int).SetBytes(word).String(), nil
+	case kindInt:
+		value := new(big.Int).SetBytes(word)
+		if word[0]&0x80 != 0 {
+			value.Sub(value, new(big.Int).Lsh(big.NewInt(1), 256))
+		}
+		return value.String(), nil
+	case kindAddress:
+		return "0x" + hex.EncodeToString(word[12:]), nil
+	case kindBool:
+		return word[31] == 1, nil
+	case kindFixedBytes:
+		return "0x" + hex.EncodeToString(word[:t.size]), nil
+	}
+	return nil, fmt.Errorf("unsupported type: %s", t)
+}
+
+// readLength reads a 32-byte word used as an offset or length
+func readLength(data []byte, offset int) (int, error) {
+	if offset+32 > len(data) {
+		return 0, fmt.Errorf("data too short")
+	}
+	value := new(big.Int).SetBytes(data[offset : offset+32])
+	if !value.IsInt64() || value.Int64() > maxDecodedLength {
+		return 0, fmt.Errorf("length %s too large", value)
+	}
+	return int(value.Int64()), nil
+}
+
+func repeat(t *abiType, count int) []*abiType {
+	types := make([]*abiType, count)
+	for i := range types {
+		types[i] = t
+	}
+	return types
+}
+
+// namedTuple returns tuple values as a map when every component is named, otherwise as a list
+func namedTuple(names []string, values []interface{}) interface{} {
+	named := make(map[string]interface{}, len(values))
+	for i, name := range names {
+		if name == "" {
+			return values
+		}
+		named[name] = values[i]
+	}
+	return named
+}
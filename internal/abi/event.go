@@ -0,0 +1,119 @@
+package abi
+
+import (
+	"encoding/hex"
+	"fmt"
+	"strings"
+
+	"github.com/huahuayu/etherscan-mcp-server/internal/evm"
+)
+
+// Event is a contract event with its signature topic
+type Event struct {
+	Name      string
+	Signature string
+	Topic     string
+	Inputs    []Argument
+}
+
+// ParseEventSignature parses a human-readable event signature such as
+// "Transfer(address indexed from,address indexed to,uint256 value)"
+func ParseEventSignature(signature string) (*Event, error) {
+	name, inputs, err := parseSignature(signature)
+	if err != nil {
+		return nil, err
+	}
+	return newEvent(name, inputs), nil
+}
+
+func newEvent(name string, inputs []Argument) *Event {
+	signature := name + "(" + joinTypes(inputs) + ")"
+	return &Event{
+		Name:      name,
+		Signature: signature,
+		Topic:     "0x" + hex.EncodeToString(evm.Keccak256([]byte(signature))),
+		Inputs:    inputs,
+	}
+}
+
+// addEvent indexes an event by topic. Events sharing a topic (such as the ERC20 and ERC721
+// Transfer events) are told apart by their number of indexed arguments.
+func (a *ABI) addEvent(event *Event) {
+	for _, existing := range a.Events[event.Topic] {
+		if existing.indexedCount() == event.indexedCount() {
+			return
+		}
+	}
+	a.Events[event.Topic] = append(a.Events[event.Topic], event)
+}
+
+// EventByTopics finds the event matching a log's topics
+func (a *ABI) EventByTopics(topics []string) (*Event, bool) {
+	if len(topics) == 0 {
+		return nil, false
+	}
+	for _, event := range a.Events[strings.ToLower(topics[0])] {
+		if event.indexedCount() == len(topics)-1 {
+			return event, true
+		}
+	}
+	return nil, false
+}
+
+func (e *Event) indexedCount() int {
+	count := 0
+	for _, input := range e.Inputs {
+		if input.Indexed {
+			count++
+		}
+	}
+	return count
+}
+
+// DecodeLog decodes the arguments of a log. Indexed arguments of dynamic types only store
+// their hash in the topic, so the hash is returned as their value.
+func (e *Event) DecodeLog(topics []string, data []byte) ([]DecodedArgument, error) {
+	if len(topics)-1 != e.indexedCount() {
+		return nil, fmt.Errorf("log has %d indexed topics, %s expects %d", len(topics)-1, e.Signature, e.indexedCount())
+	}
+
+	var nonIndexed []Argument
+	for _, input := range e.Inputs {
+		if !input.Indexed {
+			nonIndexed = append(nonIndexed, input)
+		}
+	}
+	values, err := DecodeArguments(nonIndexed, data)
+	if err != nil {
+		return nil, err
+	}
+
+	decoded := make([]DecodedArgument, 0, len(e.Inputs))
+	topic, value := 1, 0
+	for _, input := range e.Inputs {
+		if !input.Indexed {
+			decoded = append(decoded, values[value])
+			value++
+			continue
+		}
+
+		t, err := parseType(input.Type, input.Components)
+		if err != nil {
+			return nil, err
+		}
+		word, err := hex.DecodeString(strings.TrimPrefix(topics[topic], "0x"))
+		if err != nil || len(word) != 32 {
+			return nil, fmt.Errorf("invalid topic: %s", topics[topic])
+		}
+		topic++
+
+		argument := DecodedArgument{Name: input.Name, Type: t.String(), Value: "0x" + hex.EncodeToString(word)}
+		if !t.dynamic() && t.kind != kindArray && t.kind != kindTuple {
+			if argument.Value, err = decodeValue(t, word); err != nil {
+				return nil, err
+			}
+		}
+		decoded = append(decoded, argument)
+	}
+	return decoded, nil
+}
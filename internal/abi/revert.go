@@ -0,0 +1,89 @@
+package abi
+
+import (
+	"encoding/hex"
+	"fmt"
+	"math/big"
+)
+
+// Selectors of the revert payloads produced by the Solidity compiler
+const (
+	errorSelector = "0x08c379a0" // Error(string)
+	panicSelector = "0x4e487b71" // Panic(uint256)
+)
+
+// panicReasons describes the Solidity panic codes
+var panicReasons = map[uint64]string{
+	0x00: "generic compiler panic",
+	0x01: "assertion failed",
+	0x11: "arithmetic overflow or underflow",
+	0x12: "division or modulo by zero",
+	0x21: "invalid enum value",
+	0x22: "invalid storage byte array encoding",
+	0x31: "pop on empty array",
+	0x32: "array index out of bounds",
+	0x41: "out of memory",
+	0x51: "call to uninitialized internal function",
+}
+
+// Revert is a decoded revert payload
+type Revert struct {
+	Kind      string            `json:"kind"` // error, panic, custom, unknown or empty
+	Reason    string            `json:"reason,omitempty"`
+	Selector  string            `json:"selector,omitempty"`
+	Signature string            `json:"signature,omitempty"`
+	Arguments []DecodedArgument `json:"arguments,omitempty"`
+	Data      string            `json:"data,omitempty"`
+}
+
+// DecodeRevert decodes revert data as Error(string), Panic(uint256) or a custom error from errors.
+// errors may be nil.
+func DecodeRevert(data []byte, errors *ABI) *Revert {
+	if len(data) == 0 {
+		return &Revert{Kind: "empty", Reason: "reverted without a reason"}
+	}
+
+	revert := &Revert{Kind: "unknown", Data: "0x" + hex.EncodeToString(data)}
+	if len(data) < 4 {
+		return revert
+	}
+	revert.Selector = Selector(data)
+
+	switch revert.Selector {
+	case errorSelector:
+		values, err := DecodeArguments([]Argument{{Type: "string"}}, data[4:])
+		if err == nil {
+			revert.Kind = "error"
+			revert.Reason = values[0].Value.(string)
+			revert.Data = ""
+		}
+		return revert
+
+	case panicSelector:
+		if len(data) >= 36 {
+			code := new(big.Int).SetBytes(data[4:36])
+			revert.Kind = "panic"
+			revert.Reason = fmt.Sprintf("panic 0x%02x", code)
+			if code.IsUint64() {
+				if reason, ok := panicReasons[code.Uint64()]; ok {
+					revert.Reason = fmt.Sprintf("panic 0x%02x: %s", code, reason)
+				}
+			}
+			revert.Data = ""
+		}
+		return revert
+	}
+
+	if errors != nil {
+		if customError, ok := errors.Errors[revert.Selector]; ok {
+			revert.Kind = "custom"
+			revert.Signature = customError.Signature
+			revert.Reason = customError.Name
+			if arguments, err := customError.DecodeInput(data); err == nil {
+				revert.Arguments = arguments
+				revert.Data = ""
+			}
+		}
+	}
+	return revert
+}
@@ -0,0 +1,205 @@
+// Package chains is the registry of chain metadata: names, native currencies, explorers, block
+// times and well-known contracts. The built-in list is embedded from chains.json and can be
+// extended or overridden at runtime with LoadFile.
+package chains
+
+import (
+	_ "embed"
+	"encoding/json"
+	"fmt"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+//go:embed chains.json
+var embeddedChains []byte
+
+// Currency describes the native currency of a chain
+type Currency struct {
+	Name     string `json:"name"`
+	Symbol   string `json:"symbol"`
+	Decimals int    `json:"decimals"`
+}
+
+// Chain holds the metadata of one chain
+type Chain struct {
+	ID             string   `json:"chainID"`
+	Name           string   `json:"name"`
+	Aliases        []string `json:"aliases,omitempty"`
+	NativeCurrency Currency `json:"nativeCurrency"`
+	ExplorerURL    string   `json:"explorerURL,omitempty"`
+	BlockTime      float64  `json:"blockTime,omitempty"` // expected seconds per block
+	Layer          string   `json:"layer,omitempty"`     // L1, L2 or L3
+	ParentChainID  string   `json:"parentChainID,omitempty"`
+	WrappedNative  string   `json:"wrappedNative,omitempty"`
+	Multicall3     string   `json:"multicall3,omitempty"`
+	RPCURL         string   `json:"rpcURL,omitempty"` // default public RPC endpoint, if any
+}
+
+// defaultCurrency is assumed for chains missing from the registry
+var defaultCurrency = Currency{Name: "Ether", Symbol: "ETH", Decimals: 18}
+
+var (
+	registry   []Chain
+	registryMu sync.RWMutex
+)
+
+func init() {
+	loaded, err := parse(embeddedChains)
+	if err != nil {
+		panic(fmt.Sprintf("invalid embedded chain registry: %v", err))
+	}
+	registry = loaded
+}
+
+// parse decodes and validates a JSON list of chains
+func parse(data []byte) ([]Chain, error) {
+	var list []Chain
+	if err := json.Unmarshal(data, &list); err != nil {
+		return nil, err
+	}
+	for i, chain := range list {
+		if chain.ID == "" || chain.Name == "" {
+			return nil, fmt.Errorf("chain at index %d must have a chainID and a name", i)
+		}
+		if chain.NativeCurrency.Symbol == "" {
+			return nil, fmt.Errorf("chain %s must have a native currency symbol", chain.ID)
+		}
+	}
+	return list, nil
+}
+
+// LoadFile merges the chains of a JSON file into the registry. Chains already in the registry
+// are replaced by the file's entry; new chains are added.
+func LoadFile(path string) error {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return fmt.Errorf("error reading chain registry: %w", err)
+	}
+	overrides, err := parse(data)
+	if err != nil {
+		return fmt.Errorf("error parsing chain registry %s: %w", path, err)
+	}
+
+	registryMu.Lock()
+	defer registryMu.Unlock()
+	for _, chain := range overrides {
+		replaced := false
+		for i := range registry {
+			if registry[i].ID == chain.ID {
+				registry[i] = chain
+				replaced = true
+				break
+			}
+		}
+		if !replaced {
+			registry = append(registry, chain)
+		}
+	}
+	return nil
+}
+
+// Get returns the metadata of a chain
+func Get(chainID string) (Chain, bool) {
+	registryMu.RLock()
+	defer registryMu.RUnlock()
+	for _, chain := range registry {
+		if chain.ID == chainID {
+			return chain, true
+		}
+	}
+	return Chain{}, false
+}
+
+// All returns every chain in the registry, in registry order
+func All() []Chain {
+	registryMu.RLock()
+	defer registryMu.RUnlock()
+	return append([]Chain(nil), registry...)
+}
+
+// Native returns the native currency of a chain, defaulting to 18-decimal ETH for unknown chains
+func Native(chainID string) Currency {
+	if chain, ok := Get(chainID); ok {
+		return chain.NativeCurrency
+	}
+	return defaultCurrency
+}
+
+// TransactionURL returns the explorer page of a transaction, or "" when the chain has no explorer
+func TransactionURL(chainID, txHash string) string {
+	chain, ok := Get(chainID)
+	if !ok || chain.ExplorerURL == "" {
+		return ""
+	}
+	return chain.ExplorerURL + "/tx/" + txHash
+}
+
+// normalizeName folds case and separators so that "Arbitrum One", "arbitrum-one" and "arbitrum_one" match
+func normalizeName(name string) string {
+	return strings.Map(func(r rune) rune {
+		switch r {
+		case ' ', '-', '_', '.':
+			return -1
+		}
+		return r
+	}, strings.ToLower(strings.TrimSpace(name)))
+}
+
+// Resolve maps a chain ID, chain name or alias (e.g. "42161", "Arbitrum One", "arbitrum") to a
+// chain ID. Numeric IDs missing from the registry are passed through unchanged, since the
+// explorer may support chains the registry doesn't list.
+func Resolve(value string) (string, error) {
+	value = strings.TrimSpace(value)
+	if value == "" {
+		return "", fmt.Errorf("chainID must not be empty")
+	}
+	if _, err := strconv.ParseUint(value, 10, 64); err == nil {
+		return value, nil
+	}
+
+	name := normalizeName(value)
+	if name == "" {
+		return "", fmt.Errorf("unknown chain %q; use listChains to see supported chains", value)
+	}
+	registryMu.RLock()
+	defer registryMu.RUnlock()
+	for _, chain := range registry {
+		if normalizeName(chain.Name) == name {
+			return chain.ID, nil
+		}
+		for _, alias := range chain.Aliases {
+			if normalizeName(alias) == name {
+				return chain.ID, nil
+			}
+		}
+	}
+
+	// Suggest chains whose name or aliases contain the input, or are contained in it
+	var suggestions []string
+	for _, chain := range registry {
+		for _, candidate := range append([]string{chain.Name}, chain.Aliases...) {
+			candidate = normalizeName(candidate)
+			if strings.Contains(candidate, name) || strings.Contains(name, candidate) {
+				suggestions = append(suggestions, fmt.Sprintf("%s (%s)", chain.Name, chain.ID))
+				break
+			}
+		}
+	}
+	if len(suggestions) > 0 {
+		sort.Strings(suggestions)
+		return "", fmt.Errorf("unknown chain %q; did you mean %s? Use listChains to see supported chains", value, strings.Join(suggestions, ", "))
+	}
+	return "", fmt.Errorf("unknown chain %q; pass a numeric chain ID or a chain name, and use listChains to see supported chains", value)
+}
+
+// Label returns "Name (ID)" for chains in the registry, and the bare ID otherwise
+func Label(chainID string) string {
+	if chain, ok := Get(chainID); ok {
+		return fmt.Sprintf("%s (%s)", chain.Name, chain.ID)
+	}
+	return chainID
+}
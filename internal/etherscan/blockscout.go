@@ -0,0 +1,189 @@
+package etherscan
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Blockscout is the Etherscan-compatible API of a Blockscout instance (e.g. https://eth.blockscout.com/api).
+// Each instance serves a single chain and needs no API key. Blockscout has no proxy module, gas
+// tracker or name tags, so the tools backed by them fall back to RPC or report them as unavailable.
+type Blockscout struct {
+	*Client
+}
+
+// blockscoutUnsupported lists the Etherscan endpoints Blockscout doesn't offer; a "*" action covers the whole module
+var blockscoutUnsupported = map[string][]string{
+	"proxy":      {"*"},
+	"gastracker": {"*"},
+	"nametag":    {"*"},
+	"block":      {"getblockcountdown"},
+	"token":      {"tokeninfo", "tokenholdercount"},
+	"stats":      append([]string{"ethsupply2", "chainsize", "nodecount", "tokensupplyhistory"}, DailyStatsActions...),
+	"account":    {"txsBeaconWithdrawal", "txnbridge", "getdeposittxs", "getwithdrawaltxs"},
+}
+
+// NewBlockscout creates a client for a Blockscout instance's API URL; apiKey is optional
+func NewBlockscout(apiURL, apiKey string) *Blockscout {
+	apiURL = strings.TrimRight(apiURL, "/")
+	return &Blockscout{&Client{
+		name:    "Blockscout",
+		baseURL: apiURL,
+		apiKey:  apiKey,
+		keyless: true,
+		httpClient: &http.Client{
+			Timeout: 10 * time.Second,
+		},
+		chainURL:    func(string) string { return apiURL },
+		unsupported: unsupportedIn(blockscoutUnsupported),
+	}}
+}
+
+// unsupportedIn builds an unsupported-endpoint check from a module → actions list
+func unsupportedIn(endpoints map[string][]string) func(module, action string) bool {
+	return func(module, action string) bool {
+		for _, unsupported := range endpoints[module] {
+			if unsupported == "*" || unsupported == action {
+				return true
+			}
+		}
+		return false
+	}
+}
+
+// GetLatestBlockNumber gets the latest block number with Blockscout's block/eth_block_number
+func (b *Blockscout) GetLatestBlockNumber(chainID string) (string, error) {
+	result, err := b.Request(chainID, "block", "eth_block_number", nil)
+	if err != nil {
+		return "", err
+	}
+
+	var hexBlock string
+	if err := json.Unmarshal(result, &hexBlock); err != nil {
+		return "", fmt.Errorf("failed to parse block number: %w", err)
+	}
+
+	blockNumber, err := strconv.ParseUint(strings.TrimPrefix(hexBlock, "0x"), 16, 64)
+	if err != nil {
+		return hexBlock, nil
+	}
+	return strconv.FormatUint(blockNumber, 10), nil
+}
+
+// GetBlockNumberByTime gets the block closest to a timestamp from block/getblocknobytime, whose
+// result is an object rather than Etherscan's bare block number
+func (b *Blockscout) GetBlockNumberByTime(chainID, timestamp, closest string) (string, error) {
+	if closest == "" {
+		closest = "before"
+	}
+
+	result, err := b.Request(chainID, "block", "getblocknobytime", map[string]string{
+		"timestamp": timestamp,
+		"closest":   closest,
+	})
+	if err != nil {
+		return "", err
+	}
+
+	var block struct {
+		BlockNumber string `json:"blockNumber"`
+	}
+	if err := json.Unmarshal(result, &block); err != nil || block.BlockNumber == "" {
+		return "", fmt.Errorf("failed to parse block number: %s", string(result))
+	}
+	return block.BlockNumber, nil
+}
+
+// GetEthPrice gets the native token price from stats/coinprice, in Etherscan's ethprice format
+func (b *Blockscout) GetEthPrice(chainID string) (json.RawMessage, error) {
+	result, err := b.Request(chainID, "stats", "coinprice", nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var price struct {
+		CoinBTC          string `json:"coin_btc"`
+		CoinBTCTimestamp string `json:"coin_btc_timestamp"`
+		CoinUSD          string `json:"coin_usd"`
+		CoinUSDTimestamp string `json:"coin_usd_timestamp"`
+	}
+	if err := json.Unmarshal(result, &price); err != nil {
+		return nil, fmt.Errorf("failed to parse coin price: %w", err)
+	}
+
+	return json.Marshal(map[string]string{
+		"ethbtc":           price.CoinBTC,
+		"ethbtc_timestamp": price.CoinBTCTimestamp,
+		"ethusd":           price.CoinUSD,
+		"ethusd_timestamp": price.CoinUSDTimestamp,
+	})
+}
+
+// GetTokenDetails gets a token's name, symbol and decimals from token/getToken
+func (b *Blockscout) GetTokenDetails(chainID, contractAddress string) (json.RawMessage, error) {
+	if strings.EqualFold(contractAddress, NativeTokenAddress) {
+		return b.Client.GetTokenDetails(chainID, contractAddress)
+	}
+
+	result, err := b.Request(chainID, "token", "getToken", map[string]string{"contractaddress": contractAddress})
+	if err != nil {
+		return nil, err
+	}
+
+	var token struct {
+		Name     string `json:"name"`
+		Symbol   string `json:"symbol"`
+		Decimals string `json:"decimals"`
+	}
+	if err := json.Unmarshal(result, &token); err != nil {
+		return nil, fmt.Errorf("failed to parse token: %w", err)
+	}
+
+	details := TokenDetails{Name: token.Name, Symbol: token.Symbol, Decimals: 18}
+	if decimals, err := strconv.Atoi(token.Decimals); err == nil {
+		details.Decimals = decimals
+	}
+
+	detailsJSON, err := json.Marshal(details)
+	if err != nil {
+		return nil, fmt.Errorf("error serializing token details: %w", err)
+	}
+
+	// Wrap in the standard response format
+	responseJSON := fmt.Sprintf(`{"status":"1","message":"OK","result":%s}`, string(detailsJSON))
+	return []byte(responseJSON), nil
+}
+
+// GetTokenHolderList gets token holders from token/getTokenHolders, in Etherscan's tokenholderlist format
+func (b *Blockscout) GetTokenHolderList(chainID, contractAddress string, params map[string]string) (json.RawMessage, error) {
+	if params == nil {
+		params = make(map[string]string)
+	}
+	params["contractaddress"] = contractAddress
+
+	result, err := b.Request(chainID, "token", "getTokenHolders", params)
+	if err != nil {
+		return nil, err
+	}
+
+	var holders []struct {
+		Address string `json:"address"`
+		Value   string `json:"value"`
+	}
+	if err := json.Unmarshal(result, &holders); err != nil {
+		return nil, fmt.Errorf("failed to parse token holders: %w", err)
+	}
+
+	list := make([]map[string]string, 0, len(holders))
+	for _, holder := range holders {
+		list = append(list, map[string]string{
+			"TokenHolderAddress":  holder.Address,
+			"TokenHolderQuantity": holder.Value,
+		})
+	}
+	return json.Marshal(list)
+}
@@ -0,0 +1,218 @@
+package etherscan
+
+import (
+	_ "embed"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/huahuayu/etherscan-mcp-server/internal/chains"
+)
+
+// capabilitiesJSON is the per-chain endpoint availability documented in docs/blockscan_apis.md,
+// plus the chain-specific endpoints documented separately by Etherscan
+//
+//go:embed capabilities.json
+var capabilitiesJSON []byte
+
+// Endpoint describes where an explorer API endpoint is available
+type Endpoint struct {
+	Module string `json:"module"`
+	Action string `json:"action"`
+	Name   string `json:"name"`
+	Tier   string `json:"tier"` // free or pro
+	// Only lists the chains serving a chain-specific endpoint; every other chain lacks it
+	Only []string `json:"only,omitempty"`
+	// Unsupported lists documented chains lacking the endpoint
+	Unsupported []string `json:"unsupported,omitempty"`
+	// Tools lists the MCP tools backed by the endpoint
+	Tools []string `json:"tools,omitempty"`
+	// Fallback tells how the tools answer without the endpoint: rpc (needs an RPC endpoint) or estimate
+	Fallback string `json:"fallback,omitempty"`
+}
+
+// Availability of an endpoint on a chain
+const (
+	Supported   = "supported"
+	Unsupported = "unsupported"
+	Unknown     = "unknown" // the chain is not covered by the capability matrix
+)
+
+// capabilities is the parsed capability matrix
+var capabilities struct {
+	DocumentedChains []string   `json:"documentedChains"`
+	PaidPlanChains   []string   `json:"paidPlanChains"`
+	Endpoints        []Endpoint `json:"endpoints"`
+}
+
+func init() {
+	if err := json.Unmarshal(capabilitiesJSON, &capabilities); err != nil {
+		panic(fmt.Sprintf("invalid capability matrix: %v", err))
+	}
+}
+
+// ErrUnsupportedEndpoint is returned without calling the API when an endpoint is known to be missing on a chain
+var ErrUnsupportedEndpoint = errors.New("etherscan API: this endpoint is not available on this chain")
+
+// IsUnsupportedEndpointError checks if an error is caused by an endpoint missing on a chain
+func IsUnsupportedEndpointError(err error) bool {
+	return errors.Is(err, ErrUnsupportedEndpoint)
+}
+
+// IsUnavailableError checks if an error means Etherscan can't serve a request on a chain, because
+// the chain requires a paid plan, the endpoint doesn't exist there or no API key is configured.
+// Callers with an RPC equivalent fall back to it on these errors.
+func IsUnavailableError(err error) bool {
+	return IsNotFreeAPIError(err) || IsUnsupportedEndpointError(err) || errors.Is(err, ErrMissingAPIKey)
+}
+
+// Endpoints returns the capability matrix
+func Endpoints() []Endpoint {
+	return append([]Endpoint(nil), capabilities.Endpoints...)
+}
+
+// RequiresPaidPlan checks if Etherscan serves a chain only on paid plans
+func RequiresPaidPlan(chainID string) bool {
+	return contains(capabilities.PaidPlanChains, chainID)
+}
+
+// Availability reports whether an endpoint is available on a chain. Endpoints missing from the
+// matrix, such as the proxy module, are treated as supported everywhere.
+func Availability(chainID, module, action string) string {
+	endpoint, ok := findEndpoint(module, action)
+	if !ok {
+		return Supported
+	}
+	return endpoint.availability(chainID)
+}
+
+func (e Endpoint) availability(chainID string) string {
+	switch {
+	case len(e.Only) > 0:
+		if contains(e.Only, chainID) {
+			return Supported
+		}
+		return Unsupported
+	case contains(e.Unsupported, chainID):
+		return Unsupported
+	case contains(capabilities.DocumentedChains, chainID):
+		return Supported
+	}
+	return Unknown
+}
+
+// SupportedChains returns the chain IDs known to serve an endpoint
+func SupportedChains(module, action string) []string {
+	endpoint, ok := findEndpoint(module, action)
+	if !ok {
+		return nil
+	}
+	if len(endpoint.Only) > 0 {
+		return endpoint.Only
+	}
+
+	var supported []string
+	for _, chainID := range capabilities.DocumentedChains {
+		if !contains(endpoint.Unsupported, chainID) {
+			supported = append(supported, chainID)
+		}
+	}
+	return supported
+}
+
+// EndpointsFor returns the endpoints of the matrix with the given availability on a chain
+func EndpointsFor(chainID, availability string) []Endpoint {
+	var endpoints []Endpoint
+	for _, endpoint := range capabilities.Endpoints {
+		if endpoint.availability(chainID) == availability {
+			endpoints = append(endpoints, endpoint)
+		}
+	}
+	return endpoints
+}
+
+// Capabilities reports which explorer API serves a chain and what it offers there
+type Capabilities interface {
+	// ExplorerName returns the name of the explorer API serving a chain
+	ExplorerName(chainID string) string
+	// Availability reports whether the explorer serving a chain offers an endpoint there
+	Availability(chainID, module, action string) string
+	// RequiresPaidPlan checks if the explorer serving a chain serves it only on paid plans
+	RequiresPaidPlan(chainID string) bool
+}
+
+// ExplorerName returns the name of the explorer API
+func (c *Client) ExplorerName(chainID string) string {
+	return c.name
+}
+
+// Availability reports whether the explorer offers an endpoint on a chain. Etherscan follows the
+// capability matrix; other explorers offer every endpoint they don't list as unsupported, except
+// chain-specific endpoints on chains the matrix doesn't list for them.
+func (c *Client) Availability(chainID, module, action string) string {
+	if c.unsupported == nil {
+		return Availability(chainID, module, action)
+	}
+	if c.unsupported(module, action) {
+		return Unsupported
+	}
+	if endpoint, ok := findEndpoint(module, action); ok && len(endpoint.Only) > 0 {
+		return endpoint.availability(chainID)
+	}
+	return Supported
+}
+
+// RequiresPaidPlan checks if the explorer serves a chain only on paid plans; only Etherscan has paid-plan chains
+func (c *Client) RequiresPaidPlan(chainID string) bool {
+	return c.unsupported == nil && RequiresPaidPlan(chainID)
+}
+
+// checkRequest fails fast when a request can't succeed: without a required API key, or when the
+// endpoint is known to be missing on the chain
+func (c *Client) checkRequest(chainID, module, action string) error {
+	if c.apiKey == "" && !c.keyless {
+		return ErrMissingAPIKey
+	}
+	if c.unsupported != nil {
+		if c.Availability(chainID, module, action) == Unsupported {
+			return fmt.Errorf("%w: %s/%s is not offered by the %s API serving %s",
+				ErrUnsupportedEndpoint, module, action, c.name, chains.Label(chainID))
+		}
+		return nil
+	}
+	return checkEndpoint(chainID, module, action)
+}
+
+// checkEndpoint fails fast when the capability matrix says an endpoint is missing on a chain
+func checkEndpoint(chainID, module, action string) error {
+	endpoint, ok := findEndpoint(module, action)
+	if !ok || endpoint.availability(chainID) != Unsupported {
+		return nil
+	}
+
+	var supported []string
+	for _, id := range SupportedChains(module, action) {
+		supported = append(supported, chains.Label(id))
+	}
+	return fmt.Errorf("%w: %s (%s/%s) is not available on %s; supported chains: %s",
+		ErrUnsupportedEndpoint, endpoint.Name, module, action, chains.Label(chainID), strings.Join(supported, ", "))
+}
+
+func findEndpoint(module, action string) (Endpoint, bool) {
+	for _, endpoint := range capabilities.Endpoints {
+		if endpoint.Module == module && endpoint.Action == action {
+			return endpoint, true
+		}
+	}
+	return Endpoint{}, false
+}
+
+func contains(list []string, value string) bool {
+	for _, item := range list {
+		if item == value {
+			return true
+		}
+	}
+	return false
+}
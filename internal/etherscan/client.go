@@ -10,13 +10,24 @@ import (
 	"strconv"
 	"strings"
 	"time"
+
+	"github.com/huahuayu/etherscan-mcp-server/internal/chains"
 )
 
-// Client represents an Etherscan API client
+// Client represents an Etherscan API client. It also serves explorers with an Etherscan-compatible
+// API, which differ in where each chain's API lives and which endpoints they offer.
 type Client struct {
+	name       string // explorer name used in error messages
 	baseURL    string
 	apiKey     string
+	keyless    bool // requests may be sent without an API key
 	httpClient *http.Client
+	// chainURL returns the API URL of a chain for explorers with one API per chain; when nil,
+	// requests go to baseURL with the chain ID in the chainid parameter
+	chainURL func(chainID string) string
+	// unsupported reports endpoints the explorer lacks on every chain; when nil, Etherscan's
+	// per-chain capability matrix applies
+	unsupported func(module, action string) bool
 }
 
 // Response is the standard response format from Etherscan API
@@ -48,6 +59,17 @@ func IsNotFreeAPIError(err error) bool {
 	return errors.Is(err, ErrNotFreeAPI)
 }
 
+// ErrProEndpoint is returned when an endpoint requires an Etherscan API Pro plan
+var ErrProEndpoint = errors.New("etherscan API: this endpoint requires an API Pro plan")
+
+// IsProEndpointError checks if an error is caused by calling a Pro endpoint without a Pro plan
+func IsProEndpointError(err error) bool {
+	return errors.Is(err, ErrProEndpoint)
+}
+
+// ErrMissingAPIKey is returned without calling the API when no API key is configured
+var ErrMissingAPIKey = errors.New("etherscan API: no API key configured (set ETHERSCAN_API_KEY)")
+
 // Error represents an API error
 type Error struct {
 	Status  string `json:"status"`
@@ -58,9 +80,21 @@ func (e *Error) Error() string {
 	return fmt.Sprintf("etherscan API error: %s - %s", e.Status, e.Message)
 }
 
+// IsNoRecordsError checks if an error is Etherscan reporting an empty result list (e.g. "No transactions found")
+func IsNoRecordsError(err error) bool {
+	var apiErr *Error
+	return errors.As(err, &apiErr) && strings.HasPrefix(apiErr.Message, "No ")
+}
+
+// IsNotVerifiedError checks if an error is the explorer reporting that a contract's source code is not verified
+func IsNotVerifiedError(err error) bool {
+	return err != nil && strings.Contains(strings.ToLower(err.Error()), "not verified")
+}
+
 // NewClient creates a new Etherscan client
 func NewClient(apiKey string) *Client {
 	return &Client{
+		name:    "Etherscan",
 		baseURL: "https://api.etherscan.io/v2/api",
 		apiKey:  apiKey,
 		httpClient: &http.Client{
@@ -71,12 +105,12 @@ func NewClient(apiKey string) *Client {
 
 // Request performs a GET request to the Etherscan API
 func (c *Client) Request(chainID string, module, action string, params map[string]string) (json.RawMessage, error) {
+	if err := c.checkRequest(chainID, module, action); err != nil {
+		return nil, err
+	}
+
 	// Create URL values
-	values := url.Values{}
-	values.Set("module", module)
-	values.Set("action", action)
-	values.Set("apikey", c.apiKey)
-	values.Set("chainid", chainID)
+	values := c.queryValues(chainID, module, action)
 
 	// Add additional parameters
 	for k, v := range params {
@@ -85,7 +119,7 @@ func (c *Client) Request(chainID string, module, action string, params map[strin
 
 	// Create request URL with chainID as a query parameter
 	// Format: https://api.etherscan.io/v2/api?chainid=${chainid}&${other-params}
-	requestURL := fmt.Sprintf("%s?%s", c.baseURL, values.Encode())
+	requestURL := fmt.Sprintf("%s?%s", c.apiURL(chainID), values.Encode())
 
 	// Create request
 	req, err := http.NewRequest("GET", requestURL, nil)
@@ -93,19 +127,16 @@ func (c *Client) Request(chainID string, module, action string, params map[strin
 		return nil, fmt.Errorf("failed to create request: %w", err)
 	}
 
-	// Send request
-	resp, err := c.httpClient.Do(req)
+	body, err := c.send(req)
 	if err != nil {
-		return nil, fmt.Errorf("failed to send request: %w", err)
+		return nil, err
 	}
-	defer resp.Body.Close()
 
-	// Read response body
-	body, err := io.ReadAll(resp.Body)
-	if err != nil {
-		return nil, fmt.Errorf("failed to read response body: %w", err)
-	}
+	return decodeResult(body)
+}
 
+// decodeResult extracts the result of a JSON-RPC (proxy module) or standard API response, mapping errors
+func decodeResult(body []byte) (json.RawMessage, error) {
 	// First, try to parse as a JSON-RPC response (for proxy module)
 	var jsonRPCResponse JSONRPCResponse
 	if err := json.Unmarshal(body, &jsonRPCResponse); err == nil && jsonRPCResponse.JSONRPC != "" {
@@ -124,6 +155,11 @@ func (c *Client) Request(chainID string, module, action string, params map[strin
 
 	// Check for errors in standard response
 	if response.Status != "1" && response.Status != "" {
+		// Detect Pro endpoint error, which is also reported as NOTOK
+		if response.Status == "0" && strings.Contains(string(response.Result), "API Pro endpoint") {
+			return nil, fmt.Errorf("%w: %s", ErrProEndpoint, string(response.Result))
+		}
+
 		// Detect non-free API error (NOTOK typically means the chain requires a paid plan)
 		if response.Status == "0" && response.Message == "NOTOK" {
 			return nil, fmt.Errorf("%w: %s", ErrNotFreeAPI, string(response.Result))
@@ -137,6 +173,46 @@ func (c *Client) Request(chainID string, module, action string, params map[strin
 	return response.Result, nil
 }
 
+// queryValues returns the query parameters common to every request
+func (c *Client) queryValues(chainID, module, action string) url.Values {
+	values := url.Values{}
+	values.Set("module", module)
+	values.Set("action", action)
+	if c.apiKey != "" {
+		values.Set("apikey", c.apiKey)
+	}
+	if c.chainURL == nil {
+		values.Set("chainid", chainID)
+	}
+	return values
+}
+
+// apiURL returns the API URL serving a chain
+func (c *Client) apiURL(chainID string) string {
+	if c.chainURL != nil {
+		return c.chainURL(chainID)
+	}
+	return c.baseURL
+}
+
+// send performs an HTTP request and returns the response body
+func (c *Client) send(req *http.Request) ([]byte, error) {
+	// Send request
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to send request: %w", err)
+	}
+	defer resp.Body.Close()
+
+	// Read response body
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read response body: %w", err)
+	}
+
+	return body, nil
+}
+
 // GetAccountBalance gets the balance of an account on a specific blockchain
 func (c *Client) GetAccountBalance(chainID, address string) (string, error) {
 	params := map[string]string{
@@ -157,45 +233,58 @@ func (c *Client) GetAccountBalance(chainID, address string) (string, error) {
 	return balance, nil
 }
 
-// GetBlockByNumber gets block information by block number
-func (c *Client) GetBlockByNumber(chainID, blockNumber string) (json.RawMessage, error) {
-	// For non-proxy API, we don't need to convert to hex format
+// GetBlockByNumber gets a block header by block number using eth_getBlockByNumber.
+// When fullTx is true the block includes full transaction objects, otherwise only transaction hashes.
+func (c *Client) GetBlockByNumber(chainID, blockNumber string, fullTx bool) (json.RawMessage, error) {
 	params := map[string]string{
-		"blockno": blockNumber,
+		"tag":     toHexTag(blockNumber),
+		"boolean": strconv.FormatBool(fullTx),
 	}
 
-	return c.Request(chainID, "block", "getblockreward", params)
+	return c.Request(chainID, "proxy", "eth_getBlockByNumber", params)
 }
 
-// GetBlockByNumberRaw gets block information by block number using the raw RPC method
-func (c *Client) GetBlockByNumberRaw(chainID, blockNumber string) (json.RawMessage, error) {
-	// Convert blockNumber to proper format if it's "latest"
-	tag := blockNumber
-	if blockNumber == "latest" {
-		tag = "latest"
-	} else {
-		// If it's a number, convert to hex format (required by eth_getBlockByNumber)
-		if _, err := strconv.ParseUint(blockNumber, 10, 64); err == nil {
-			blockNum, _ := strconv.ParseUint(blockNumber, 10, 64)
-			tag = fmt.Sprintf("0x%x", blockNum)
-		}
+// GetBlockRewards gets block rewards by block number
+func (c *Client) GetBlockRewards(chainID, blockNumber string) (json.RawMessage, error) {
+	params := map[string]string{
+		"blockno": blockNumber,
 	}
 
+	return c.Request(chainID, "block", "getblockreward", params)
+}
+
+// GetBlockCountdown gets the estimated time remaining until a future block is mined
+func (c *Client) GetBlockCountdown(chainID, blockNumber string) (json.RawMessage, error) {
 	params := map[string]string{
-		"tag":     tag,
-		"boolean": "true", // Include full transaction objects
+		"blockno": blockNumber,
 	}
 
-	return c.Request(chainID, "proxy", "eth_getBlockByNumber", params)
+	return c.Request(chainID, "block", "getblockcountdown", params)
 }
 
-// GetBlockRewards gets block rewards by block number
-func (c *Client) GetBlockRewards(chainID, blockNumber string) (json.RawMessage, error) {
+// GetBlockNumberByTime gets the block number mined closest to a unix timestamp.
+// closest is either "before" or "after".
+func (c *Client) GetBlockNumberByTime(chainID, timestamp, closest string) (string, error) {
+	if closest == "" {
+		closest = "before"
+	}
+
 	params := map[string]string{
-		"blockno": blockNumber,
+		"timestamp": timestamp,
+		"closest":   closest,
 	}
 
-	return c.Request(chainID, "block", "getblockreward", params)
+	result, err := c.Request(chainID, "block", "getblocknobytime", params)
+	if err != nil {
+		return "", err
+	}
+
+	var blockNumber string
+	if err := json.Unmarshal(result, &blockNumber); err != nil {
+		return "", fmt.Errorf("failed to parse block number: %w", err)
+	}
+
+	return blockNumber, nil
 }
 
 // GetContractABI gets the ABI for a verified contract
@@ -240,11 +329,110 @@ func (c *Client) ExecuteContractMethod(chainID, contractAddress, methodABI, meth
 	return c.Request(chainID, "proxy", "eth_call", params)
 }
 
+// GetCode gets the deployed bytecode at an address
+func (c *Client) GetCode(chainID, address, tag string) (string, error) {
+	if tag == "" {
+		tag = "latest"
+	}
+
+	params := map[string]string{
+		"address": address,
+		"tag":     toHexTag(tag),
+	}
+
+	result, err := c.Request(chainID, "proxy", "eth_getCode", params)
+	if err != nil {
+		return "", err
+	}
+
+	var code string
+	if err := json.Unmarshal(result, &code); err != nil {
+		return "", fmt.Errorf("failed to parse code: %w", err)
+	}
+
+	return code, nil
+}
+
+// GetStorageAt gets the 32-byte value stored at a storage slot of an address
+func (c *Client) GetStorageAt(chainID, address, position, tag string) (string, error) {
+	if tag == "" {
+		tag = "latest"
+	}
+
+	params := map[string]string{
+		"address":  address,
+		"position": position,
+		"tag":      toHexTag(tag),
+	}
+
+	result, err := c.Request(chainID, "proxy", "eth_getStorageAt", params)
+	if err != nil {
+		return "", err
+	}
+
+	var value string
+	if err := json.Unmarshal(result, &value); err != nil {
+		return "", fmt.Errorf("failed to parse storage value: %w", err)
+	}
+
+	return value, nil
+}
+
 // GetGasOracle gets current gas price oracle output
 func (c *Client) GetGasOracle(chainID string) (json.RawMessage, error) {
 	return c.Request(chainID, "gastracker", "gasoracle", nil)
 }
 
+// GetGasEstimate gets the estimated confirmation time in seconds for a gas price in wei
+func (c *Client) GetGasEstimate(chainID, gasPrice string) (string, error) {
+	params := map[string]string{
+		"gasprice": gasPrice,
+	}
+
+	result, err := c.Request(chainID, "gastracker", "gasestimate", params)
+	if err != nil {
+		return "", err
+	}
+
+	var seconds string
+	if err := json.Unmarshal(result, &seconds); err != nil {
+		return "", fmt.Errorf("failed to parse gas estimate: %w", err)
+	}
+
+	return seconds, nil
+}
+
+// GetGasPrice gets the current gas price in wei as a hex quantity
+func (c *Client) GetGasPrice(chainID string) (string, error) {
+	result, err := c.Request(chainID, "proxy", "eth_gasPrice", nil)
+	if err != nil {
+		return "", err
+	}
+
+	var gasPrice string
+	if err := json.Unmarshal(result, &gasPrice); err != nil {
+		return "", fmt.Errorf("failed to parse gas price: %w", err)
+	}
+
+	return gasPrice, nil
+}
+
+// EstimateGas estimates the gas needed for a call. params may contain to, from, data, value, gas
+// and gasPrice, with numeric values given as hex quantities.
+func (c *Client) EstimateGas(chainID string, params map[string]string) (string, error) {
+	result, err := c.Request(chainID, "proxy", "eth_estimateGas", params)
+	if err != nil {
+		return "", err
+	}
+
+	var gas string
+	if err := json.Unmarshal(result, &gas); err != nil {
+		return "", fmt.Errorf("failed to parse gas estimate: %w", err)
+	}
+
+	return gas, nil
+}
+
 // GetTokenBalance gets the token balance of an account
 func (c *Client) GetTokenBalance(chainID, contractAddress, address string) (string, error) {
 	params := map[string]string{
@@ -277,26 +465,9 @@ func (c *Client) GetTransactionByHash(chainID, txHash string) (json.RawMessage,
 
 // GetTransactionByBlockNumberAndIndex gets a transaction by block number and index
 func (c *Client) GetTransactionByBlockNumberAndIndex(chainID, blockNumber, index string) (json.RawMessage, error) {
-	// Convert blockNumber to proper format if it's not "latest"
-	tag := blockNumber
-	if blockNumber != "latest" {
-		// If it's a number, convert to hex format (required by eth_getTransactionByBlockNumberAndIndex)
-		if _, err := strconv.ParseUint(blockNumber, 10, 64); err == nil {
-			blockNum, _ := strconv.ParseUint(blockNumber, 10, 64)
-			tag = fmt.Sprintf("0x%x", blockNum)
-		}
-	}
-
-	// Convert index to hex format if it's a number
-	idx := index
-	if _, err := strconv.ParseUint(index, 10, 64); err == nil {
-		indexNum, _ := strconv.ParseUint(index, 10, 64)
-		idx = fmt.Sprintf("0x%x", indexNum)
-	}
-
 	params := map[string]string{
-		"tag":   tag,
-		"index": idx,
+		"tag":   toHexTag(blockNumber),
+		"index": toHexTag(index),
 	}
 
 	return c.Request(chainID, "proxy", "eth_getTransactionByBlockNumberAndIndex", params)
@@ -325,6 +496,49 @@ func (c *Client) GetTransactionReceipt(chainID, txHash string) (json.RawMessage,
 	return c.Request(chainID, "proxy", "eth_getTransactionReceipt", params)
 }
 
+// GetLogs gets the event logs in a block range. params may hold address, topic0 to topic3 and the
+// topicX_Y_opr operators combining them. An empty list is returned when no logs match.
+func (c *Client) GetLogs(chainID, fromBlock, toBlock string, params map[string]string) (json.RawMessage, error) {
+	query := map[string]string{
+		"fromBlock": fromBlock,
+		"toBlock":   toBlock,
+	}
+	for k, v := range params {
+		query[k] = v
+	}
+
+	result, err := c.Request(chainID, "logs", "getLogs", query)
+	if IsNoRecordsError(err) {
+		return json.RawMessage("[]"), nil
+	}
+	return result, err
+}
+
+// SendRawTransaction broadcasts a signed transaction and returns its hash
+func (c *Client) SendRawTransaction(chainID, signedTx string) (string, error) {
+	params := map[string]string{
+		"hex": signedTx,
+	}
+
+	// POST the transaction, since blob and large-calldata transactions exceed URL length limits
+	body, err := c.sendForm(chainID, "proxy", "eth_sendRawTransaction", params)
+	if err != nil {
+		return "", err
+	}
+
+	result, err := decodeResult(body)
+	if err != nil {
+		return "", err
+	}
+
+	var txHash string
+	if err := json.Unmarshal(result, &txHash); err != nil {
+		return "", fmt.Errorf("failed to parse transaction hash: %w", err)
+	}
+
+	return txHash, nil
+}
+
 // GetTransactionStatus gets contract execution status for a transaction
 func (c *Client) GetTransactionStatus(chainID, txHash string) (json.RawMessage, error) {
 	params := map[string]string{
@@ -354,6 +568,15 @@ func (c *Client) GetInternalTransactionsByAddress(chainID, address string, param
 	return c.Request(chainID, "account", "txlistinternal", params)
 }
 
+// GetInternalTransactionsByHash gets the internal transactions of a transaction
+func (c *Client) GetInternalTransactionsByHash(chainID, txHash string) (json.RawMessage, error) {
+	params := map[string]string{
+		"txhash": txHash,
+	}
+
+	return c.Request(chainID, "account", "txlistinternal", params)
+}
+
 // GetTokenTransfersByAddress gets list of token transfers by address
 func (c *Client) GetTokenTransfersByAddress(chainID, address string, params map[string]string) (json.RawMessage, error) {
 	if params == nil {
@@ -374,6 +597,198 @@ func (c *Client) GetERC721Transfers(chainID, address string, params map[string]s
 	return c.Request(chainID, "account", "tokennfttx", params)
 }
 
+// GetMinedBlocks gets list of blocks validated/mined by an address.
+// blockType is either "blocks" for canonical blocks or "uncles" for uncle blocks.
+func (c *Client) GetMinedBlocks(chainID, address, blockType string, params map[string]string) (json.RawMessage, error) {
+	if params == nil {
+		params = make(map[string]string)
+	}
+	if blockType == "" {
+		blockType = "blocks"
+	}
+	params["address"] = address
+	params["blocktype"] = blockType
+
+	return c.Request(chainID, "account", "getminedblocks", params)
+}
+
+// GetBeaconWithdrawals gets list of beacon chain withdrawals made to an address
+func (c *Client) GetBeaconWithdrawals(chainID, address string, params map[string]string) (json.RawMessage, error) {
+	return c.requestChainSpecific(chainID, "txsBeaconWithdrawal", address, params)
+}
+
+// GetPlasmaDeposits gets list of Polygon plasma bridge deposits by address
+func (c *Client) GetPlasmaDeposits(chainID, address string, params map[string]string) (json.RawMessage, error) {
+	return c.requestChainSpecific(chainID, "txnbridge", address, params)
+}
+
+// GetDepositTransactions gets list of L1 to L2 deposit transactions by address
+func (c *Client) GetDepositTransactions(chainID, address string, params map[string]string) (json.RawMessage, error) {
+	return c.requestChainSpecific(chainID, "getdeposittxs", address, params)
+}
+
+// GetWithdrawalTransactions gets list of withdrawal transactions by address (Ethereum only)
+func (c *Client) GetWithdrawalTransactions(chainID, address string, params map[string]string) (json.RawMessage, error) {
+	return c.requestChainSpecific(chainID, "getwithdrawaltxs", address, params)
+}
+
+// requestChainSpecific calls a chain-specific account action; Request rejects chains that lack it
+func (c *Client) requestChainSpecific(chainID, action, address string, params map[string]string) (json.RawMessage, error) {
+	if params == nil {
+		params = make(map[string]string)
+	}
+	params["address"] = address
+
+	return c.Request(chainID, "account", action, params)
+}
+
+// DailyStatsActions lists the stats module actions that return a daily time series
+var DailyStatsActions = []string{
+	"dailytx",             // Daily transaction count
+	"dailyavggaslimit",    // Daily average gas limit
+	"dailygasused",        // Daily total gas used
+	"dailyavggasprice",    // Daily average gas price
+	"dailytxnfee",         // Daily network transaction fee
+	"dailynewaddress",     // Daily new address count
+	"dailynetutilization", // Daily network utilization
+	"dailyblkcount",       // Daily block count and rewards
+	"dailyblockrewards",   // Daily block rewards
+	"dailyavgblocktime",   // Daily average block time
+	"dailyavgblocksize",   // Daily average block size
+	"ethdailyprice",       // Daily native token price
+}
+
+// GetEthSupply gets the total supply of the native token in wei
+func (c *Client) GetEthSupply(chainID string) (json.RawMessage, error) {
+	return c.Request(chainID, "stats", "ethsupply", nil)
+}
+
+// GetEthSupply2 gets the native token supply including staking rewards, burnt fees and withdrawals
+func (c *Client) GetEthSupply2(chainID string) (json.RawMessage, error) {
+	return c.Request(chainID, "stats", "ethsupply2", nil)
+}
+
+// GetEthPrice gets the latest price of the native token in BTC and USD
+func (c *Client) GetEthPrice(chainID string) (json.RawMessage, error) {
+	return c.Request(chainID, "stats", "ethprice", nil)
+}
+
+// GetChainSize gets the size of the blockchain in bytes over a date range
+func (c *Client) GetChainSize(chainID string, params map[string]string) (json.RawMessage, error) {
+	if params == nil {
+		params = make(map[string]string)
+	}
+	if params["clienttype"] == "" {
+		params["clienttype"] = "geth"
+	}
+	if params["syncmode"] == "" {
+		params["syncmode"] = "default"
+	}
+
+	return c.Request(chainID, "stats", "chainsize", params)
+}
+
+// GetNodeCount gets the total number of discoverable nodes
+func (c *Client) GetNodeCount(chainID string) (json.RawMessage, error) {
+	return c.Request(chainID, "stats", "nodecount", nil)
+}
+
+// GetDailyStats gets a daily time series for one of the DailyStatsActions over a date range
+func (c *Client) GetDailyStats(chainID, action string, params map[string]string) (json.RawMessage, error) {
+	valid := false
+	for _, a := range DailyStatsActions {
+		if a == action {
+			valid = true
+			break
+		}
+	}
+	if !valid {
+		return nil, fmt.Errorf("unknown daily stats metric: %s", action)
+	}
+
+	return c.Request(chainID, "stats", action, params)
+}
+
+// GetTokenHolderList gets the holders of an ERC20 token and their balances
+func (c *Client) GetTokenHolderList(chainID, contractAddress string, params map[string]string) (json.RawMessage, error) {
+	if params == nil {
+		params = make(map[string]string)
+	}
+	params["contractaddress"] = contractAddress
+
+	return c.Request(chainID, "token", "tokenholderlist", params)
+}
+
+// GetTokenHolderCount gets the number of addresses holding an ERC20 token
+func (c *Client) GetTokenHolderCount(chainID, contractAddress string) (string, error) {
+	params := map[string]string{
+		"contractaddress": contractAddress,
+	}
+
+	result, err := c.Request(chainID, "token", "tokenholdercount", params)
+	if err != nil {
+		return "", err
+	}
+
+	var count string
+	if err := json.Unmarshal(result, &count); err != nil {
+		return "", fmt.Errorf("failed to parse token holder count: %w", err)
+	}
+
+	return count, nil
+}
+
+// GetTokenSupply gets the total supply of an ERC20 token
+func (c *Client) GetTokenSupply(chainID, contractAddress string) (string, error) {
+	params := map[string]string{
+		"contractaddress": contractAddress,
+	}
+
+	result, err := c.Request(chainID, "stats", "tokensupply", params)
+	if err != nil {
+		return "", err
+	}
+
+	var supply string
+	if err := json.Unmarshal(result, &supply); err != nil {
+		return "", fmt.Errorf("failed to parse token supply: %w", err)
+	}
+
+	return supply, nil
+}
+
+// GetTokenSupplyHistory gets the total supply of an ERC20 token at a given block
+func (c *Client) GetTokenSupplyHistory(chainID, contractAddress, blockNumber string) (string, error) {
+	params := map[string]string{
+		"contractaddress": contractAddress,
+		"blockno":         blockNumber,
+	}
+
+	result, err := c.Request(chainID, "stats", "tokensupplyhistory", params)
+	if err != nil {
+		return "", err
+	}
+
+	var supply string
+	if err := json.Unmarshal(result, &supply); err != nil {
+		return "", fmt.Errorf("failed to parse token supply: %w", err)
+	}
+
+	return supply, nil
+}
+
+// GetAddressTag gets the name tag and labels Etherscan attributes to an address
+func (c *Client) GetAddressTag(chainID, address string) (json.RawMessage, error) {
+	params := map[string]string{
+		"address": address,
+	}
+
+	return c.Request(chainID, "nametag", "getaddresstag", params)
+}
+
+// NativeTokenAddress is the placeholder address standing for a chain's native currency in token tools
+const NativeTokenAddress = "0xeeeeeeeeeeeeeeeeeeeeeeeeeeeeeeeeeeeeeeee"
+
 // TokenDetails represents ERC20 token details
 type TokenDetails struct {
 	Name     string `json:"name"`
@@ -384,94 +799,17 @@ type TokenDetails struct {
 // GetTokenDetails gets comprehensive token information
 func (c *Client) GetTokenDetails(chainID, contractAddress string) (json.RawMessage, error) {
 	// Handle special addresses for native tokens
-	if strings.EqualFold(contractAddress, "0xeeeeeeeeeeeeeeeeeeeeeeeeeeeeeeeeeeeeeeee") {
-		// Native ETH/chain token
-		nativeSymbol := "ETH" // Default
-
-		// Try to get proper chain symbol based on chainID
-		switch chainID {
-		case "1": // Ethereum Mainnet
-			nativeSymbol = "ETH"
-		case "56": // Binance Smart Chain
-			nativeSymbol = "BNB"
-		case "137": // Polygon
-			nativeSymbol = "MATIC"
-		case "42161": // Arbitrum One
-			nativeSymbol = "ETH"
-		case "10": // Optimism
-			nativeSymbol = "ETH"
-		case "43114": // Avalanche C-Chain
-			nativeSymbol = "AVAX"
-		case "8453": // Base
-			nativeSymbol = "ETH"
-		case "324": // zkSync Era
-			nativeSymbol = "ETH"
-		case "100": // Gnosis
-			nativeSymbol = "xDAI"
-		case "250": // Fantom
-			nativeSymbol = "FTM"
-		case "5000": // Mantle
-			nativeSymbol = "MNT"
-		case "25": // Cronos
-			nativeSymbol = "CRO"
-		case "1101": // Polygon ZkEVM
-			nativeSymbol = "ETH"
-		case "59144": // Linea
-			nativeSymbol = "ETH"
-		case "1284": // Moonbeam
-			nativeSymbol = "GLMR"
-		case "42220": // Celo
-			nativeSymbol = "CELO"
-		case "534352": // Scroll
-			nativeSymbol = "ETH"
-		case "204": // OpBNB
-			nativeSymbol = "BNB"
-		case "1285": // Moonriver
-			nativeSymbol = "MOVR"
-		case "42170": // Arbitrum Nova
-			nativeSymbol = "ETH"
-		case "81457": // Blast
-			nativeSymbol = "ETH"
-		case "252": // Fraxtal
-			nativeSymbol = "frxETH"
-		case "1111": // Wemix
-			nativeSymbol = "WEMIX"
-		case "660279": // Xai
-			nativeSymbol = "XAI"
-		case "480": // World Chain
-			nativeSymbol = "ETH"
-		case "33139": // Ape
-			nativeSymbol = "APE"
-		case "255": // Kroma
-			nativeSymbol = "ETH"
-		case "167000": // Taiko
-			nativeSymbol = "ETH"
-		case "199": // Bittorrent
-			nativeSymbol = "BTT"
-		case "50": // Xdc
-			nativeSymbol = "XDC"
-		}
-
+	if strings.EqualFold(contractAddress, NativeTokenAddress) {
+		// Native chain token, in the same response format as contract tokens
+		native := chains.Native(chainID)
 		details := TokenDetails{
-			Name:     nativeSymbol,
-			Symbol:   nativeSymbol,
-			Decimals: 18,
+			Name:     native.Name,
+			Symbol:   native.Symbol,
+			Decimals: native.Decimals,
 		}
 
 		detailsJSON, _ := json.Marshal(details)
-		return detailsJSON, nil
-	}
-
-	// Special case for USDT on Ethereum
-	if chainID == "1" && strings.EqualFold(contractAddress, "0xdAC17F958D2ee523a2206206994597C13D831ec7") {
-		details := TokenDetails{
-			Name:     "Tether USD",
-			Symbol:   "USDT",
-			Decimals: 6,
-		}
-
-		detailsJSON, _ := json.Marshal(details)
-		return detailsJSON, nil
+		return []byte(fmt.Sprintf(`{"status":"1","message":"OK","result":%s}`, detailsJSON)), nil
 	}
 
 	// Try primary method first - token info endpoint
@@ -499,7 +837,11 @@ func (c *Client) GetTokenDetails(chainID, contractAddress string) (json.RawMessa
 	}
 
 	// Try to get token name
-	nameResult, _ := c.ExecuteContractMethod(chainID, contractAddress, "0x06fdde03", "") // name()
+	nameResult, err := c.ExecuteContractMethod(chainID, contractAddress, "0x06fdde03", "") // name()
+	if IsUnavailableError(err) {
+		// The chain can't be served at all, so let the caller use another backend
+		return nil, err
+	}
 	if nameResult != nil {
 		var hexValue string
 		if err := json.Unmarshal(nameResult, &hexValue); err == nil && hexValue != "" {
@@ -586,6 +928,15 @@ func decodeAbiString(hexData string) string {
 	return string(bytes)
 }
 
+// toHexTag converts a decimal number to the hex quantity format required by proxy endpoints.
+// Block tags such as "latest" and values that are already hex are returned unchanged.
+func toHexTag(value string) string {
+	if num, err := strconv.ParseUint(value, 10, 64); err == nil {
+		return fmt.Sprintf("0x%x", num)
+	}
+	return value
+}
+
 // hexToBytes converts a hex string to bytes
 func hexToBytes(hexStr string) ([]byte, error) {
 	length := len(hexStr)
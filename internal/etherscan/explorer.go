@@ -0,0 +1,395 @@
+package etherscan
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+	"sync"
+
+	"github.com/huahuayu/etherscan-mcp-server/internal/chains"
+)
+
+// Explorer is a block explorer API in Etherscan's format. Client implements it for Etherscan V2,
+// Blockscout and Routescan for their Etherscan-compatible APIs, and Router picks one per chain.
+type Explorer interface {
+	// Accounts
+	GetAccountBalance(chainID, address string) (string, error)
+	GetTokenBalance(chainID, contractAddress, address string) (string, error)
+	GetTransactionsByAddress(chainID, address string, params map[string]string) (json.RawMessage, error)
+	GetInternalTransactionsByAddress(chainID, address string, params map[string]string) (json.RawMessage, error)
+	GetTokenTransfersByAddress(chainID, address string, params map[string]string) (json.RawMessage, error)
+	GetERC721Transfers(chainID, address string, params map[string]string) (json.RawMessage, error)
+	GetMinedBlocks(chainID, address, blockType string, params map[string]string) (json.RawMessage, error)
+	GetBeaconWithdrawals(chainID, address string, params map[string]string) (json.RawMessage, error)
+	GetPlasmaDeposits(chainID, address string, params map[string]string) (json.RawMessage, error)
+	GetDepositTransactions(chainID, address string, params map[string]string) (json.RawMessage, error)
+	GetWithdrawalTransactions(chainID, address string, params map[string]string) (json.RawMessage, error)
+
+	// Blocks
+	GetBlockByNumber(chainID, blockNumber string, fullTx bool) (json.RawMessage, error)
+	GetLatestBlockNumber(chainID string) (string, error)
+	GetBlockRewards(chainID, blockNumber string) (json.RawMessage, error)
+	GetBlockCountdown(chainID, blockNumber string) (json.RawMessage, error)
+	GetBlockNumberByTime(chainID, timestamp, closest string) (string, error)
+
+	// Contracts
+	GetContractABI(chainID, contractAddress string) (string, error)
+	GetContractSourceCode(chainID, contractAddress string) (json.RawMessage, error)
+	GetContractSourceBundle(chainID, contractAddress string) (*SourceBundle, error)
+	ExecuteContractMethod(chainID, contractAddress, methodABI, methodParams string) (json.RawMessage, error)
+	GetCode(chainID, address, tag string) (string, error)
+	GetStorageAt(chainID, address, position, tag string) (string, error)
+	VerifySourceCode(chainID string, params map[string]string) (string, error)
+	CheckVerifyStatus(chainID, guid string) (*VerificationStatus, error)
+	VerifyProxyContract(chainID, address, expectedImplementation string) (string, error)
+	CheckProxyVerification(chainID, guid string) (*VerificationStatus, error)
+
+	// Transactions and logs
+	GetTransactionByHash(chainID, txHash string) (json.RawMessage, error)
+	GetTransactionByBlockNumberAndIndex(chainID, blockNumber, index string) (json.RawMessage, error)
+	GetTransactionCount(chainID, address, tag string) (json.RawMessage, error)
+	GetTransactionReceipt(chainID, txHash string) (json.RawMessage, error)
+	GetTransactionStatus(chainID, txHash string) (json.RawMessage, error)
+	GetInternalTransactionsByHash(chainID, txHash string) (json.RawMessage, error)
+	GetLogs(chainID, fromBlock, toBlock string, params map[string]string) (json.RawMessage, error)
+	SendRawTransaction(chainID, signedTx string) (string, error)
+
+	// Gas
+	GetGasOracle(chainID string) (json.RawMessage, error)
+	GetGasEstimate(chainID, gasPrice string) (string, error)
+	GetGasPrice(chainID string) (string, error)
+	EstimateGas(chainID string, params map[string]string) (string, error)
+
+	// Tokens
+	GetTokenDetails(chainID, contractAddress string) (json.RawMessage, error)
+	GetTokenSupply(chainID, contractAddress string) (string, error)
+	GetTokenSupplyHistory(chainID, contractAddress, blockNumber string) (string, error)
+	GetTokenHolderList(chainID, contractAddress string, params map[string]string) (json.RawMessage, error)
+	GetTokenHolderCount(chainID, contractAddress string) (string, error)
+
+	// Stats and labels
+	GetEthSupply(chainID string) (json.RawMessage, error)
+	GetEthSupply2(chainID string) (json.RawMessage, error)
+	GetEthPrice(chainID string) (json.RawMessage, error)
+	GetChainSize(chainID string, params map[string]string) (json.RawMessage, error)
+	GetNodeCount(chainID string) (json.RawMessage, error)
+	GetDailyStats(chainID, action string, params map[string]string) (json.RawMessage, error)
+	GetAddressTag(chainID, address string) (json.RawMessage, error)
+
+	// Generic requests
+	Request(chainID string, module, action string, params map[string]string) (json.RawMessage, error)
+
+	// Capabilities
+	Capabilities
+}
+
+var (
+	_ Explorer = (*Client)(nil)
+	_ Explorer = (*Blockscout)(nil)
+	_ Explorer = (*Routescan)(nil)
+	_ Explorer = (*Router)(nil)
+)
+
+// Explorer kinds accepted by ParseExplorers
+const (
+	KindEtherscan  = "etherscan"
+	KindBlockscout = "blockscout"
+	KindRoutescan  = "routescan"
+)
+
+// ExplorerConfig selects the explorer API serving a chain
+type ExplorerConfig struct {
+	Kind string
+	// Endpoint is the API URL for Blockscout, and mainnet or testnet for Routescan
+	Endpoint string
+}
+
+// ParseExplorers parses a comma-separated list of chain=kind[:endpoint] pairs, with chains given
+// by ID or name (e.g. "100=blockscout:https://gnosis.blockscout.com/api,43114=routescan")
+func ParseExplorers(spec string) (map[string]ExplorerConfig, error) {
+	configs := make(map[string]ExplorerConfig)
+	for _, entry := range strings.Split(spec, ",") {
+		entry = strings.TrimSpace(entry)
+		if entry == "" {
+			continue
+		}
+		chain, value, ok := strings.Cut(entry, "=")
+		if !ok || strings.TrimSpace(value) == "" {
+			return nil, fmt.Errorf("invalid explorer %q, expected chain=kind[:endpoint]", entry)
+		}
+		chainID, err := chains.Resolve(chain)
+		if err != nil {
+			return nil, err
+		}
+		kind, endpoint, _ := strings.Cut(strings.TrimSpace(value), ":")
+		kind = strings.ToLower(kind)
+		switch kind {
+		case KindEtherscan, KindBlockscout, KindRoutescan:
+		default:
+			return nil, fmt.Errorf("unknown explorer %q for chain %s (expected %s, %s or %s)", kind, chainID, KindEtherscan, KindBlockscout, KindRoutescan)
+		}
+		configs[chainID] = ExplorerConfig{Kind: kind, Endpoint: endpoint}
+	}
+	return configs, nil
+}
+
+// NewExplorer creates the explorer described by a config. etherscan reuses the given Etherscan client.
+func NewExplorer(config ExplorerConfig, etherscanClient *Client, apiKey string) (Explorer, error) {
+	switch config.Kind {
+	case KindEtherscan:
+		return etherscanClient, nil
+	case KindBlockscout:
+		if config.Endpoint == "" {
+			return nil, fmt.Errorf("blockscout explorer needs an API URL, e.g. blockscout:https://eth.blockscout.com/api")
+		}
+		return NewBlockscout(config.Endpoint, apiKey), nil
+	case KindRoutescan:
+		return NewRoutescan(config.Endpoint, apiKey)
+	}
+	return nil, fmt.Errorf("unknown explorer %q (expected %s, %s or %s)", config.Kind, KindEtherscan, KindBlockscout, KindRoutescan)
+}
+
+// Router is an Explorer that sends each chain's requests to the explorer configured for it,
+// and the requests of every other chain to a default explorer
+type Router struct {
+	defaultExplorer Explorer
+	mu              sync.RWMutex
+	explorers       map[string]Explorer
+}
+
+// NewRouter creates a Router sending every chain to defaultExplorer until configured otherwise
+func NewRouter(defaultExplorer Explorer) *Router {
+	return &Router{defaultExplorer: defaultExplorer, explorers: make(map[string]Explorer)}
+}
+
+// SetExplorer sets the explorer serving a chain
+func (r *Router) SetExplorer(chainID string, explorer Explorer) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.explorers[chainID] = explorer
+}
+
+func (r *Router) explorer(chainID string) Explorer {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	if explorer, ok := r.explorers[chainID]; ok {
+		return explorer
+	}
+	return r.defaultExplorer
+}
+
+func (r *Router) GetAccountBalance(chainID, address string) (string, error) {
+	return r.explorer(chainID).GetAccountBalance(chainID, address)
+}
+
+func (r *Router) GetTokenBalance(chainID, contractAddress, address string) (string, error) {
+	return r.explorer(chainID).GetTokenBalance(chainID, contractAddress, address)
+}
+
+func (r *Router) GetTransactionsByAddress(chainID, address string, params map[string]string) (json.RawMessage, error) {
+	return r.explorer(chainID).GetTransactionsByAddress(chainID, address, params)
+}
+
+func (r *Router) GetInternalTransactionsByAddress(chainID, address string, params map[string]string) (json.RawMessage, error) {
+	return r.explorer(chainID).GetInternalTransactionsByAddress(chainID, address, params)
+}
+
+func (r *Router) GetTokenTransfersByAddress(chainID, address string, params map[string]string) (json.RawMessage, error) {
+	return r.explorer(chainID).GetTokenTransfersByAddress(chainID, address, params)
+}
+
+func (r *Router) GetERC721Transfers(chainID, address string, params map[string]string) (json.RawMessage, error) {
+	return r.explorer(chainID).GetERC721Transfers(chainID, address, params)
+}
+
+func (r *Router) GetMinedBlocks(chainID, address, blockType string, params map[string]string) (json.RawMessage, error) {
+	return r.explorer(chainID).GetMinedBlocks(chainID, address, blockType, params)
+}
+
+func (r *Router) GetBeaconWithdrawals(chainID, address string, params map[string]string) (json.RawMessage, error) {
+	return r.explorer(chainID).GetBeaconWithdrawals(chainID, address, params)
+}
+
+func (r *Router) GetPlasmaDeposits(chainID, address string, params map[string]string) (json.RawMessage, error) {
+	return r.explorer(chainID).GetPlasmaDeposits(chainID, address, params)
+}
+
+func (r *Router) GetDepositTransactions(chainID, address string, params map[string]string) (json.RawMessage, error) {
+	return r.explorer(chainID).GetDepositTransactions(chainID, address, params)
+}
+
+func (r *Router) GetWithdrawalTransactions(chainID, address string, params map[string]string) (json.RawMessage, error) {
+	return r.explorer(chainID).GetWithdrawalTransactions(chainID, address, params)
+}
+
+func (r *Router) GetBlockByNumber(chainID, blockNumber string, fullTx bool) (json.RawMessage, error) {
+	return r.explorer(chainID).GetBlockByNumber(chainID, blockNumber, fullTx)
+}
+
+func (r *Router) GetLatestBlockNumber(chainID string) (string, error) {
+	return r.explorer(chainID).GetLatestBlockNumber(chainID)
+}
+
+func (r *Router) GetBlockRewards(chainID, blockNumber string) (json.RawMessage, error) {
+	return r.explorer(chainID).GetBlockRewards(chainID, blockNumber)
+}
+
+func (r *Router) GetBlockCountdown(chainID, blockNumber string) (json.RawMessage, error) {
+	return r.explorer(chainID).GetBlockCountdown(chainID, blockNumber)
+}
+
+func (r *Router) GetBlockNumberByTime(chainID, timestamp, closest string) (string, error) {
+	return r.explorer(chainID).GetBlockNumberByTime(chainID, timestamp, closest)
+}
+
+func (r *Router) GetContractABI(chainID, contractAddress string) (string, error) {
+	return r.explorer(chainID).GetContractABI(chainID, contractAddress)
+}
+
+func (r *Router) GetContractSourceCode(chainID, contractAddress string) (json.RawMessage, error) {
+	return r.explorer(chainID).GetContractSourceCode(chainID, contractAddress)
+}
+
+func (r *Router) GetContractSourceBundle(chainID, contractAddress string) (*SourceBundle, error) {
+	return r.explorer(chainID).GetContractSourceBundle(chainID, contractAddress)
+}
+
+func (r *Router) ExecuteContractMethod(chainID, contractAddress, methodABI, methodParams string) (json.RawMessage, error) {
+	return r.explorer(chainID).ExecuteContractMethod(chainID, contractAddress, methodABI, methodParams)
+}
+
+func (r *Router) GetCode(chainID, address, tag string) (string, error) {
+	return r.explorer(chainID).GetCode(chainID, address, tag)
+}
+
+func (r *Router) GetStorageAt(chainID, address, position, tag string) (string, error) {
+	return r.explorer(chainID).GetStorageAt(chainID, address, position, tag)
+}
+
+func (r *Router) VerifySourceCode(chainID string, params map[string]string) (string, error) {
+	return r.explorer(chainID).VerifySourceCode(chainID, params)
+}
+
+func (r *Router) CheckVerifyStatus(chainID, guid string) (*VerificationStatus, error) {
+	return r.explorer(chainID).CheckVerifyStatus(chainID, guid)
+}
+
+func (r *Router) VerifyProxyContract(chainID, address, expectedImplementation string) (string, error) {
+	return r.explorer(chainID).VerifyProxyContract(chainID, address, expectedImplementation)
+}
+
+func (r *Router) CheckProxyVerification(chainID, guid string) (*VerificationStatus, error) {
+	return r.explorer(chainID).CheckProxyVerification(chainID, guid)
+}
+
+func (r *Router) GetTransactionByHash(chainID, txHash string) (json.RawMessage, error) {
+	return r.explorer(chainID).GetTransactionByHash(chainID, txHash)
+}
+
+func (r *Router) GetTransactionByBlockNumberAndIndex(chainID, blockNumber, index string) (json.RawMessage, error) {
+	return r.explorer(chainID).GetTransactionByBlockNumberAndIndex(chainID, blockNumber, index)
+}
+
+func (r *Router) GetTransactionCount(chainID, address, tag string) (json.RawMessage, error) {
+	return r.explorer(chainID).GetTransactionCount(chainID, address, tag)
+}
+
+func (r *Router) GetTransactionReceipt(chainID, txHash string) (json.RawMessage, error) {
+	return r.explorer(chainID).GetTransactionReceipt(chainID, txHash)
+}
+
+func (r *Router) GetTransactionStatus(chainID, txHash string) (json.RawMessage, error) {
+	return r.explorer(chainID).GetTransactionStatus(chainID, txHash)
+}
+
+func (r *Router) GetInternalTransactionsByHash(chainID, txHash string) (json.RawMessage, error) {
+	return r.explorer(chainID).GetInternalTransactionsByHash(chainID, txHash)
+}
+
+func (r *Router) GetLogs(chainID, fromBlock, toBlock string, params map[string]string) (json.RawMessage, error) {
+	return r.explorer(chainID).GetLogs(chainID, fromBlock, toBlock, params)
+}
+
+func (r *Router) SendRawTransaction(chainID, signedTx string) (string, error) {
+	return r.explorer(chainID).SendRawTransaction(chainID, signedTx)
+}
+
+func (r *Router) GetGasOracle(chainID string) (json.RawMessage, error) {
+	return r.explorer(chainID).GetGasOracle(chainID)
+}
+
+func (r *Router) GetGasEstimate(chainID, gasPrice string) (string, error) {
+	return r.explorer(chainID).GetGasEstimate(chainID, gasPrice)
+}
+
+func (r *Router) GetGasPrice(chainID string) (string, error) {
+	return r.explorer(chainID).GetGasPrice(chainID)
+}
+
+func (r *Router) EstimateGas(chainID string, params map[string]string) (string, error) {
+	return r.explorer(chainID).EstimateGas(chainID, params)
+}
+
+func (r *Router) GetTokenDetails(chainID, contractAddress string) (json.RawMessage, error) {
+	return r.explorer(chainID).GetTokenDetails(chainID, contractAddress)
+}
+
+func (r *Router) GetTokenSupply(chainID, contractAddress string) (string, error) {
+	return r.explorer(chainID).GetTokenSupply(chainID, contractAddress)
+}
+
+func (r *Router) GetTokenSupplyHistory(chainID, contractAddress, blockNumber string) (string, error) {
+	return r.explorer(chainID).GetTokenSupplyHistory(chainID, contractAddress, blockNumber)
+}
+
+func (r *Router) GetTokenHolderList(chainID, contractAddress string, params map[string]string) (json.RawMessage, error) {
+	return r.explorer(chainID).GetTokenHolderList(chainID, contractAddress, params)
+}
+
+func (r *Router) GetTokenHolderCount(chainID, contractAddress string) (string, error) {
+	return r.explorer(chainID).GetTokenHolderCount(chainID, contractAddress)
+}
+
+func (r *Router) GetEthSupply(chainID string) (json.RawMessage, error) {
+	return r.explorer(chainID).GetEthSupply(chainID)
+}
+
+func (r *Router) GetEthSupply2(chainID string) (json.RawMessage, error) {
+	return r.explorer(chainID).GetEthSupply2(chainID)
+}
+
+func (r *Router) GetEthPrice(chainID string) (json.RawMessage, error) {
+	return r.explorer(chainID).GetEthPrice(chainID)
+}
+
+func (r *Router) GetChainSize(chainID string, params map[string]string) (json.RawMessage, error) {
+	return r.explorer(chainID).GetChainSize(chainID, params)
+}
+
+func (r *Router) GetNodeCount(chainID string) (json.RawMessage, error) {
+	return r.explorer(chainID).GetNodeCount(chainID)
+}
+
+func (r *Router) GetDailyStats(chainID, action string, params map[string]string) (json.RawMessage, error) {
+	return r.explorer(chainID).GetDailyStats(chainID, action, params)
+}
+
+func (r *Router) GetAddressTag(chainID, address string) (json.RawMessage, error) {
+	return r.explorer(chainID).GetAddressTag(chainID, address)
+}
+
+func (r *Router) Request(chainID string, module, action string, params map[string]string) (json.RawMessage, error) {
+	return r.explorer(chainID).Request(chainID, module, action, params)
+}
+
+func (r *Router) ExplorerName(chainID string) string {
+	return r.explorer(chainID).ExplorerName(chainID)
+}
+
+func (r *Router) Availability(chainID, module, action string) string {
+	return r.explorer(chainID).Availability(chainID, module, action)
+}
+
+func (r *Router) RequiresPaidPlan(chainID string) bool {
+	return r.explorer(chainID).RequiresPaidPlan(chainID)
+}
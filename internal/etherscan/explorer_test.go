@@ -0,0 +1,156 @@
+package etherscan
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestParseExplorers(t *testing.T) {
+	tests := []struct {
+		name    string
+		spec    string
+		want    map[string]ExplorerConfig
+		wantErr string
+	}{
+		{
+			name: "ids and names",
+			spec: "gnosis=blockscout:https://gnosis.blockscout.com/api, 43114=routescan,1=Etherscan",
+			want: map[string]ExplorerConfig{
+				"100":   {Kind: KindBlockscout, Endpoint: "https://gnosis.blockscout.com/api"},
+				"43114": {Kind: KindRoutescan},
+				"1":     {Kind: KindEtherscan},
+			},
+		},
+		{
+			name: "routescan network and empty entries",
+			spec: ",43113=routescan:testnet,",
+			want: map[string]ExplorerConfig{"43113": {Kind: KindRoutescan, Endpoint: "testnet"}},
+		},
+		{name: "missing kind", spec: "100=", wantErr: "expected chain=kind"},
+		{name: "missing separator", spec: "blockscout", wantErr: "expected chain=kind"},
+		{name: "unknown kind", spec: "100=subscan", wantErr: "unknown explorer"},
+		{name: "unknown chain", spec: "nochain=routescan", wantErr: "unknown chain"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ParseExplorers(tt.spec)
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("ParseExplorers() error = %v, want %q", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("ParseExplorers() error = %v", err)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("got %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestNewExplorer(t *testing.T) {
+	etherscanClient := NewClient("key")
+
+	tests := []struct {
+		config   ExplorerConfig
+		wantName string
+		wantErr  string
+	}{
+		{config: ExplorerConfig{Kind: KindEtherscan}, wantName: "Etherscan"},
+		{config: ExplorerConfig{Kind: KindBlockscout, Endpoint: "http://localhost:4000/api"}, wantName: "Blockscout"},
+		{config: ExplorerConfig{Kind: KindBlockscout}, wantErr: "needs an API URL"},
+		{config: ExplorerConfig{Kind: KindRoutescan}, wantName: "Routescan"},
+		{config: ExplorerConfig{Kind: KindRoutescan, Endpoint: "devnet"}, wantErr: "unknown Routescan network"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.config.Kind+":"+tt.config.Endpoint, func(t *testing.T) {
+			explorer, err := NewExplorer(tt.config, etherscanClient, "")
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("NewExplorer() error = %v, want %q", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("NewExplorer() error = %v", err)
+			}
+			if got := explorer.ExplorerName("1"); got != tt.wantName {
+				t.Errorf("ExplorerName() = %s, want %s", got, tt.wantName)
+			}
+		})
+	}
+}
+
+func TestRouter(t *testing.T) {
+	router := NewRouter(NewClient("key"))
+	router.SetExplorer("8453", NewBlockscout("http://localhost:4000/api", ""))
+
+	tests := []struct {
+		name string
+		got  func() interface{}
+		want interface{}
+	}{
+		{"default explorer", func() interface{} { return router.ExplorerName("1") }, "Etherscan"},
+		{"configured explorer", func() interface{} { return router.ExplorerName("8453") }, "Blockscout"},
+		{"etherscan paid plan", func() interface{} { return NewClient("key").RequiresPaidPlan("8453") }, true},
+		{"routed chain without paid plan", func() interface{} { return router.RequiresPaidPlan("8453") }, false},
+		{"etherscan proxy module", func() interface{} { return router.Availability("1", "proxy", "eth_call") }, Supported},
+		{"blockscout proxy module", func() interface{} { return router.Availability("8453", "proxy", "eth_call") }, Unsupported},
+		{"blockscout account module", func() interface{} { return router.Availability("8453", "account", "balance") }, Supported},
+		{"chain-specific endpoint elsewhere", func() interface{} { return router.Availability("8453", "account", "getwithdrawaltxs") }, Unsupported},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.got(); got != tt.want {
+				t.Errorf("got %v, want %v", got, tt.want)
+			}
+		})
+	}
+
+	// Requests to a module the routed explorer lacks fail without calling it
+	if _, err := router.GetGasPrice("8453"); !IsUnsupportedEndpointError(err) {
+		t.Errorf("GetGasPrice() error = %v, want an unsupported endpoint error", err)
+	}
+}
+
+// blockscoutServer serves canned Blockscout responses keyed by module/action
+func blockscoutServer(t *testing.T, responses map[string]string) *Blockscout {
+	t.Helper()
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		query := r.URL.Query()
+		response, ok := responses[query.Get("module")+"/"+query.Get("action")]
+		if !ok {
+			t.Errorf("unexpected request %s", r.URL)
+			http.NotFound(w, r)
+			return
+		}
+		w.Write([]byte(response))
+	}))
+	t.Cleanup(server.Close)
+	return NewBlockscout(server.URL+"/api", "")
+}
+
+func TestBlockscoutBlocks(t *testing.T) {
+	blockscout := blockscoutServer(t, map[string]string{
+		"block/getblocknobytime": `{"message":"OK","result":{"blockNumber":"38290120"},"status":"1"}`,
+		"block/eth_block_number": `{"id":1,"jsonrpc":"2.0","result":"0x2484b6e"}`,
+	})
+
+	blockNumber, err := blockscout.GetBlockNumberByTime("100", "1700000000", "")
+	if err != nil || blockNumber != "38290120" {
+		t.Errorf("GetBlockNumberByTime() = %q, %v, want 38290120", blockNumber, err)
+	}
+
+	latest, err := blockscout.GetLatestBlockNumber("100")
+	if err != nil || latest != "38292334" {
+		t.Errorf("GetLatestBlockNumber() = %q, %v, want 38292334", latest, err)
+	}
+}
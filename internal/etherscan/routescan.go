@@ -0,0 +1,46 @@
+package etherscan
+
+import (
+	"fmt"
+	"net/http"
+	"time"
+)
+
+// Routescan is Routescan's Etherscan-compatible API, serving every chain Routescan indexes
+// (Avalanche and its L1s among others) without an API key at a reduced rate limit
+type Routescan struct {
+	*Client
+}
+
+// routescanUnsupported lists the Etherscan endpoints Routescan doesn't offer; a "*" action covers the whole module
+var routescanUnsupported = map[string][]string{
+	"nametag": {"*"},
+	"stats":   append([]string{"chainsize", "nodecount"}, DailyStatsActions...),
+	"account": {"txsBeaconWithdrawal", "txnbridge", "getdeposittxs", "getwithdrawaltxs"},
+}
+
+// NewRoutescan creates a Routescan client for the mainnet (default) or testnet network; apiKey is optional
+func NewRoutescan(network, apiKey string) (*Routescan, error) {
+	switch network {
+	case "":
+		network = "mainnet"
+	case "mainnet", "testnet":
+	default:
+		return nil, fmt.Errorf("unknown Routescan network %q (expected mainnet or testnet)", network)
+	}
+
+	baseURL := "https://api.routescan.io/v2/network/" + network + "/evm"
+	return &Routescan{&Client{
+		name:    "Routescan",
+		baseURL: baseURL,
+		apiKey:  apiKey,
+		keyless: true,
+		httpClient: &http.Client{
+			Timeout: 10 * time.Second,
+		},
+		chainURL: func(chainID string) string {
+			return fmt.Sprintf("%s/%s/etherscan/api", baseURL, chainID)
+		},
+		unsupported: unsupportedIn(routescanUnsupported),
+	}}, nil
+}
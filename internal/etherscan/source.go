@@ -0,0 +1,225 @@
+package etherscan
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// SourceBundle is a normalized view of a verified contract's source code. Etherscan returns the
+// source either as a single file, as a JSON map of files, or as a Solidity standard JSON input
+// wrapped in an extra pair of braces; all three are flattened into Files.
+type SourceBundle struct {
+	ContractName         string            `json:"contractName"`
+	Language             string            `json:"language"`
+	CompilerVersion      string            `json:"compilerVersion"`
+	OptimizationUsed     bool              `json:"optimizationUsed"`
+	Runs                 int               `json:"runs"`
+	EVMVersion           string            `json:"evmVersion,omitempty"`
+	LicenseType          string            `json:"licenseType,omitempty"`
+	Proxy                bool              `json:"proxy"`
+	Implementation       string            `json:"implementation,omitempty"`
+	ConstructorArguments string            `json:"constructorArguments,omitempty"`
+	Libraries            map[string]string `json:"libraries,omitempty"`
+	Remappings           []string          `json:"remappings,omitempty"`
+	Settings             json.RawMessage   `json:"settings,omitempty"`
+	Files                map[string]string `json:"files"`
+	ABI                  string            `json:"-"`
+}
+
+// sourceCodeResult is a single entry of the getsourcecode response
+type sourceCodeResult struct {
+	SourceCode           string `json:"SourceCode"`
+	ABI                  string `json:"ABI"`
+	ContractName         string `json:"ContractName"`
+	CompilerVersion      string `json:"CompilerVersion"`
+	OptimizationUsed     string `json:"OptimizationUsed"`
+	Runs                 string `json:"Runs"`
+	ConstructorArguments string `json:"ConstructorArguments"`
+	EVMVersion           string `json:"EVMVersion"`
+	Library              string `json:"Library"`
+	LicenseType          string `json:"LicenseType"`
+	Proxy                string `json:"Proxy"`
+	Implementation       string `json:"Implementation"`
+}
+
+// standardJSONInput is the subset of the Solidity standard JSON input used by the bundle
+type standardJSONInput struct {
+	Language string                     `json:"language"`
+	Sources  map[string]sourceFile      `json:"sources"`
+	Settings map[string]json.RawMessage `json:"settings"`
+}
+
+// sourceFile is a single file of a multi-file source
+type sourceFile struct {
+	Content string `json:"content"`
+}
+
+// GetContractSourceBundle gets the source code of a verified contract as a normalized SourceBundle
+func (c *Client) GetContractSourceBundle(chainID, contractAddress string) (*SourceBundle, error) {
+	result, err := c.GetContractSourceCode(chainID, contractAddress)
+	if err != nil {
+		return nil, err
+	}
+
+	return ParseSourceBundle(result)
+}
+
+// ParseSourceBundle normalizes a getsourcecode result into a SourceBundle
+func ParseSourceBundle(result json.RawMessage) (*SourceBundle, error) {
+	var entries []sourceCodeResult
+	if err := json.Unmarshal(result, &entries); err != nil {
+		return nil, fmt.Errorf("failed to parse source code: %w", err)
+	}
+	if len(entries) == 0 || entries[0].SourceCode == "" {
+		return nil, fmt.Errorf("contract source code not verified")
+	}
+	entry := entries[0]
+
+	bundle := &SourceBundle{
+		ContractName:         entry.ContractName,
+		Language:             "Solidity",
+		CompilerVersion:      entry.CompilerVersion,
+		OptimizationUsed:     entry.OptimizationUsed == "1",
+		EVMVersion:           entry.EVMVersion,
+		LicenseType:          entry.LicenseType,
+		Proxy:                entry.Proxy == "1",
+		Implementation:       entry.Implementation,
+		ConstructorArguments: entry.ConstructorArguments,
+		Libraries:            parseLibraries(entry.Library),
+		Files:                make(map[string]string),
+		ABI:                  entry.ABI,
+	}
+	bundle.Runs, _ = strconv.Atoi(entry.Runs)
+	if strings.HasPrefix(strings.ToLower(entry.CompilerVersion), "vyper") {
+		bundle.Language = "Vyper"
+	}
+
+	source := strings.TrimSpace(entry.SourceCode)
+	switch {
+	case strings.HasPrefix(source, "{{") && strings.HasSuffix(source, "}}"):
+		// Standard JSON input wrapped in an extra pair of braces
+		var input standardJSONInput
+		if err := json.Unmarshal([]byte(source[1:len(source)-1]), &input); err != nil {
+			return nil, fmt.Errorf("failed to parse standard JSON input: %w", err)
+		}
+		if input.Language != "" {
+			bundle.Language = input.Language
+		}
+		for path, file := range input.Sources {
+			bundle.Files[path] = file.Content
+		}
+		bundle.applySettings(input.Settings)
+
+	case strings.HasPrefix(source, "{"):
+		// Plain JSON map of files
+		var files map[string]sourceFile
+		if err := json.Unmarshal([]byte(source), &files); err != nil {
+			return nil, fmt.Errorf("failed to parse multi-file source: %w", err)
+		}
+		for path, file := range files {
+			bundle.Files[path] = file.Content
+		}
+
+	default:
+		extension := ".sol"
+		if bundle.Language == "Vyper" {
+			extension = ".vy"
+		}
+		bundle.Files[entry.ContractName+extension] = entry.SourceCode
+	}
+
+	return bundle, nil
+}
+
+// Paths returns the file paths of the bundle in sorted order
+func (b *SourceBundle) Paths() []string {
+	paths := make([]string, 0, len(b.Files))
+	for path := range b.Files {
+		paths = append(paths, path)
+	}
+	sort.Strings(paths)
+	return paths
+}
+
+// FindFile looks up a file by exact path, or by path suffix when the suffix is unambiguous
+func (b *SourceBundle) FindFile(path string) (string, string, error) {
+	if content, ok := b.Files[path]; ok {
+		return path, content, nil
+	}
+
+	var matches []string
+	for _, candidate := range b.Paths() {
+		if strings.HasSuffix(candidate, "/"+strings.TrimPrefix(path, "/")) {
+			matches = append(matches, candidate)
+		}
+	}
+
+	switch len(matches) {
+	case 0:
+		return "", "", fmt.Errorf("file %s not found in source bundle", path)
+	case 1:
+		return matches[0], b.Files[matches[0]], nil
+	default:
+		return "", "", fmt.Errorf("file %s is ambiguous, matches: %s", path, strings.Join(matches, ", "))
+	}
+}
+
+// applySettings extracts compiler settings, remappings and linked libraries from standard JSON settings
+func (b *SourceBundle) applySettings(settings map[string]json.RawMessage) {
+	if len(settings) == 0 {
+		return
+	}
+
+	if raw, ok := settings["remappings"]; ok {
+		_ = json.Unmarshal(raw, &b.Remappings)
+	}
+
+	if raw, ok := settings["libraries"]; ok {
+		var libraries map[string]map[string]string
+		if err := json.Unmarshal(raw, &libraries); err == nil {
+			for file, names := range libraries {
+				for name, address := range names {
+					if b.Libraries == nil {
+						b.Libraries = make(map[string]string)
+					}
+					b.Libraries[file+":"+name] = address
+				}
+			}
+		}
+	}
+
+	if raw, ok := settings["evmVersion"]; ok && b.EVMVersion == "" {
+		_ = json.Unmarshal(raw, &b.EVMVersion)
+	}
+
+	// Keep the remaining compiler settings, minus the bulky output selection
+	remaining := make(map[string]json.RawMessage)
+	for key, value := range settings {
+		if key != "outputSelection" && key != "remappings" && key != "libraries" {
+			remaining[key] = value
+		}
+	}
+	b.Settings, _ = json.Marshal(remaining)
+}
+
+// parseLibraries parses Etherscan's Library field ("Name:address;Name:address")
+func parseLibraries(field string) map[string]string {
+	if field == "" {
+		return nil
+	}
+
+	libraries := make(map[string]string)
+	for _, entry := range strings.Split(field, ";") {
+		name, address, ok := strings.Cut(strings.TrimSpace(entry), ":")
+		if ok && name != "" {
+			libraries[name] = address
+		}
+	}
+	if len(libraries) == 0 {
+		return nil
+	}
+	return libraries
+}
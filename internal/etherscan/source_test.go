@@ -0,0 +1,196 @@
+package etherscan
+
+import (
+	"encoding/json"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+// sourceResult builds a getsourcecode result holding one entry
+func sourceResult(t *testing.T, entry sourceCodeResult) json.RawMessage {
+	t.Helper()
+	result, err := json.Marshal([]sourceCodeResult{entry})
+	if err != nil {
+		t.Fatalf("failed to encode source result: %v", err)
+	}
+	return result
+}
+
+func TestParseSourceBundle(t *testing.T) {
+	standardJSON := `{{
+		"language": "Solidity",
+		"sources": {
+			"contracts/Token.sol": {"content": "contract Token {}"},
+			"@openzeppelin/contracts/token/ERC20/ERC20.sol": {"content": "contract ERC20 {}"}
+		},
+		"settings": {
+			"optimizer": {"enabled": true, "runs": 200},
+			"evmVersion": "paris",
+			"remappings": ["@openzeppelin/=lib/openzeppelin-contracts/"],
+			"libraries": {"contracts/Math.sol": {"Math": "0x00000000000000000000000000000000000000aa"}},
+			"outputSelection": {"*": {"*": ["abi"]}}
+		}
+	}}`
+
+	tests := []struct {
+		name    string
+		entry   sourceCodeResult
+		check   func(t *testing.T, bundle *SourceBundle)
+		wantErr string
+	}{
+		{
+			name: "standard JSON input",
+			entry: sourceCodeResult{
+				SourceCode:       standardJSON,
+				ContractName:     "Token",
+				CompilerVersion:  "v0.8.20+commit.a1b79de6",
+				OptimizationUsed: "1",
+				Runs:             "200",
+			},
+			check: func(t *testing.T, bundle *SourceBundle) {
+				wantPaths := []string{"@openzeppelin/contracts/token/ERC20/ERC20.sol", "contracts/Token.sol"}
+				if !reflect.DeepEqual(bundle.Paths(), wantPaths) {
+					t.Errorf("Paths() = %v, want %v", bundle.Paths(), wantPaths)
+				}
+				if bundle.Files["contracts/Token.sol"] != "contract Token {}" {
+					t.Errorf("got Token.sol %q", bundle.Files["contracts/Token.sol"])
+				}
+				if !bundle.OptimizationUsed || bundle.Runs != 200 || bundle.EVMVersion != "paris" {
+					t.Errorf("got optimization %v runs %d evm %q, want true 200 paris", bundle.OptimizationUsed, bundle.Runs, bundle.EVMVersion)
+				}
+				if !reflect.DeepEqual(bundle.Remappings, []string{"@openzeppelin/=lib/openzeppelin-contracts/"}) {
+					t.Errorf("got remappings %v", bundle.Remappings)
+				}
+				if bundle.Libraries["contracts/Math.sol:Math"] != "0x00000000000000000000000000000000000000aa" {
+					t.Errorf("got libraries %v", bundle.Libraries)
+				}
+				var settings map[string]json.RawMessage
+				if err := json.Unmarshal(bundle.Settings, &settings); err != nil {
+					t.Fatalf("invalid settings %s: %v", bundle.Settings, err)
+				}
+				if _, ok := settings["optimizer"]; !ok {
+					t.Errorf("settings %s lack the optimizer", bundle.Settings)
+				}
+				for _, dropped := range []string{"outputSelection", "remappings", "libraries"} {
+					if _, ok := settings[dropped]; ok {
+						t.Errorf("settings %s keep %s", bundle.Settings, dropped)
+					}
+				}
+			},
+		},
+		{
+			name: "plain JSON map of files",
+			entry: sourceCodeResult{
+				SourceCode:     `{"Vault.sol": {"content": "contract Vault {}"}, "lib/Safe.sol": {"content": "library Safe {}"}}`,
+				ContractName:   "Vault",
+				Library:        "Safe:0x00000000000000000000000000000000000000bb",
+				Proxy:          "1",
+				Implementation: "0x00000000000000000000000000000000000000cc",
+			},
+			check: func(t *testing.T, bundle *SourceBundle) {
+				if !reflect.DeepEqual(bundle.Paths(), []string{"Vault.sol", "lib/Safe.sol"}) {
+					t.Errorf("Paths() = %v", bundle.Paths())
+				}
+				if bundle.Language != "Solidity" || bundle.Settings != nil {
+					t.Errorf("got language %q settings %s, want Solidity without settings", bundle.Language, bundle.Settings)
+				}
+				if bundle.Libraries["Safe"] != "0x00000000000000000000000000000000000000bb" {
+					t.Errorf("got libraries %v", bundle.Libraries)
+				}
+				if !bundle.Proxy || bundle.Implementation != "0x00000000000000000000000000000000000000cc" {
+					t.Errorf("got proxy %v implementation %q", bundle.Proxy, bundle.Implementation)
+				}
+			},
+		},
+		{
+			name:  "single Solidity file",
+			entry: sourceCodeResult{SourceCode: "pragma solidity ^0.4.24;\ncontract Old {}", ContractName: "Old", CompilerVersion: "v0.4.24+commit.e67f0147"},
+			check: func(t *testing.T, bundle *SourceBundle) {
+				if !reflect.DeepEqual(bundle.Files, map[string]string{"Old.sol": "pragma solidity ^0.4.24;\ncontract Old {}"}) {
+					t.Errorf("got files %v", bundle.Files)
+				}
+			},
+		},
+		{
+			name:  "single Vyper file",
+			entry: sourceCodeResult{SourceCode: "# @version 0.3.7\n", ContractName: "Pool", CompilerVersion: "vyper:0.3.7"},
+			check: func(t *testing.T, bundle *SourceBundle) {
+				if bundle.Language != "Vyper" || bundle.Files["Pool.vy"] == "" {
+					t.Errorf("got language %q files %v, want Vyper with Pool.vy", bundle.Language, bundle.Files)
+				}
+			},
+		},
+		{
+			name:    "not verified",
+			entry:   sourceCodeResult{ABI: "Contract source code not verified"},
+			wantErr: "not verified",
+		},
+		{
+			name:    "malformed standard JSON input",
+			entry:   sourceCodeResult{SourceCode: `{{"sources": [}}`, ContractName: "Broken"},
+			wantErr: "failed to parse standard JSON input",
+		},
+		{
+			name:    "malformed multi-file source",
+			entry:   sourceCodeResult{SourceCode: `{"A.sol": "contract A {}"}`, ContractName: "A"},
+			wantErr: "failed to parse multi-file source",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			bundle, err := ParseSourceBundle(sourceResult(t, tt.entry))
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("ParseSourceBundle() error = %v, want %q", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("ParseSourceBundle() error = %v", err)
+			}
+			tt.check(t, bundle)
+		})
+	}
+}
+
+func TestSourceBundleFindFile(t *testing.T) {
+	bundle := &SourceBundle{Files: map[string]string{
+		"contracts/Token.sol":           "token",
+		"contracts/interfaces/IERC.sol": "interface",
+		"lib/a/IERC20.sol":              "a",
+		"lib/b/IERC20.sol":              "b",
+	}}
+
+	tests := []struct {
+		path     string
+		wantPath string
+		wantErr  string
+	}{
+		{path: "contracts/Token.sol", wantPath: "contracts/Token.sol"},
+		{path: "Token.sol", wantPath: "contracts/Token.sol"},
+		{path: "/interfaces/IERC.sol", wantPath: "contracts/interfaces/IERC.sol"},
+		{path: "IERC20.sol", wantErr: "ambiguous"},
+		{path: "Missing.sol", wantErr: "not found"},
+		{path: "ken.sol", wantErr: "not found"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.path, func(t *testing.T) {
+			path, content, err := bundle.FindFile(tt.path)
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("FindFile() error = %v, want %q", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("FindFile() error = %v", err)
+			}
+			if path != tt.wantPath || content != bundle.Files[tt.wantPath] {
+				t.Errorf("got %s %q, want %s", path, content, tt.wantPath)
+			}
+		})
+	}
+}
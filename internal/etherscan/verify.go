@@ -0,0 +1,192 @@
+package etherscan
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+)
+
+// Verification states reported by checkverifystatus and checkproxyverification
+const (
+	VerificationPending  = "pending"
+	VerificationPassed   = "verified"
+	VerificationFailed   = "failed"
+	VerificationAlready  = "already_verified"
+	VerificationNotFound = "unknown_guid"
+)
+
+// VerificationStatus is the state of a source code or proxy verification submission
+type VerificationStatus struct {
+	GUID    string `json:"guid"`
+	State   string `json:"state"`
+	Message string `json:"message"`
+}
+
+// Done reports whether the verification has reached a final state
+func (s *VerificationStatus) Done() bool {
+	return s.State != VerificationPending
+}
+
+// VerifySourceCode submits a Solidity standard JSON input for verification and returns the submission GUID.
+// params must include sourceCode, contractaddress, contractname (path:Name) and compilerversion.
+func (c *Client) VerifySourceCode(chainID string, params map[string]string) (string, error) {
+	form := map[string]string{
+		"codeformat": "solidity-standard-json-input",
+	}
+	for k, v := range params {
+		form[k] = v
+	}
+
+	response, err := c.postForm(chainID, "contract", "verifysourcecode", form)
+	if err != nil {
+		return "", err
+	}
+
+	return submissionGUID(response)
+}
+
+// CheckVerifyStatus gets the state of a source code verification submission
+func (c *Client) CheckVerifyStatus(chainID, guid string) (*VerificationStatus, error) {
+	response, err := c.getResponse(chainID, "contract", "checkverifystatus", map[string]string{"guid": guid})
+	if err != nil {
+		return nil, err
+	}
+
+	return parseVerificationStatus(guid, response)
+}
+
+// VerifyProxyContract asks Etherscan to link a proxy to its implementation and returns the submission GUID.
+// expectedImplementation is optional.
+func (c *Client) VerifyProxyContract(chainID, address, expectedImplementation string) (string, error) {
+	form := map[string]string{
+		"address": address,
+	}
+	if expectedImplementation != "" {
+		form["expectedimplementation"] = expectedImplementation
+	}
+
+	response, err := c.postForm(chainID, "contract", "verifyproxycontract", form)
+	if err != nil {
+		return "", err
+	}
+
+	return submissionGUID(response)
+}
+
+// CheckProxyVerification gets the state of a proxy verification submission
+func (c *Client) CheckProxyVerification(chainID, guid string) (*VerificationStatus, error) {
+	response, err := c.getResponse(chainID, "contract", "checkproxyverification", map[string]string{"guid": guid})
+	if err != nil {
+		return nil, err
+	}
+
+	return parseVerificationStatus(guid, response)
+}
+
+// postForm performs a form-encoded POST request, as required for large verification payloads.
+// The status is not interpreted, since the contracts module reports failures in the result text.
+func (c *Client) postForm(chainID, module, action string, params map[string]string) (*Response, error) {
+	body, err := c.sendForm(chainID, module, action, params)
+	if err != nil {
+		return nil, err
+	}
+
+	return parseResponse(body)
+}
+
+// sendForm performs a form-encoded POST request and returns the response body
+func (c *Client) sendForm(chainID, module, action string, params map[string]string) ([]byte, error) {
+	if err := c.checkRequest(chainID, module, action); err != nil {
+		return nil, err
+	}
+
+	form := url.Values{}
+	for k, v := range params {
+		form.Set(k, v)
+	}
+
+	requestURL := fmt.Sprintf("%s?%s", c.apiURL(chainID), c.queryValues(chainID, module, action).Encode())
+	req, err := http.NewRequest("POST", requestURL, strings.NewReader(form.Encode()))
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+
+	return c.send(req)
+}
+
+// getResponse performs a GET request and returns the response without interpreting its status
+func (c *Client) getResponse(chainID, module, action string, params map[string]string) (*Response, error) {
+	if err := c.checkRequest(chainID, module, action); err != nil {
+		return nil, err
+	}
+
+	values := c.queryValues(chainID, module, action)
+	for k, v := range params {
+		values.Set(k, v)
+	}
+
+	req, err := http.NewRequest("GET", fmt.Sprintf("%s?%s", c.apiURL(chainID), values.Encode()), nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+
+	body, err := c.send(req)
+	if err != nil {
+		return nil, err
+	}
+
+	return parseResponse(body)
+}
+
+// parseResponse parses a standard Etherscan API response
+func parseResponse(body []byte) (*Response, error) {
+	var response Response
+	if err := json.Unmarshal(body, &response); err != nil {
+		return nil, fmt.Errorf("failed to parse response: %w", err)
+	}
+	return &response, nil
+}
+
+// submissionGUID extracts the GUID from a verification submission response
+func submissionGUID(response *Response) (string, error) {
+	var result string
+	_ = json.Unmarshal(response.Result, &result)
+
+	if response.Status != "1" {
+		if result == "" {
+			result = response.Message
+		}
+		return "", fmt.Errorf("verification submission rejected: %s", result)
+	}
+
+	return result, nil
+}
+
+// parseVerificationStatus maps the free-text verification result to a VerificationStatus. Only an unknown
+// GUID counts as not found; "Unable to locate ContractCode" is a failed verification, reported with the upstream message.
+func parseVerificationStatus(guid string, response *Response) (*VerificationStatus, error) {
+	var result string
+	if err := json.Unmarshal(response.Result, &result); err != nil {
+		return nil, fmt.Errorf("failed to parse verification status: %w", err)
+	}
+
+	status := &VerificationStatus{GUID: guid, Message: result}
+	lower := strings.ToLower(result)
+	switch {
+	case strings.Contains(lower, "pending") || strings.Contains(lower, "in progress"):
+		status.State = VerificationPending
+	case strings.Contains(lower, "already verified"):
+		status.State = VerificationAlready
+	case strings.Contains(lower, "unknown uid"):
+		status.State = VerificationNotFound
+	case response.Status == "1":
+		status.State = VerificationPassed
+	default:
+		status.State = VerificationFailed
+	}
+
+	return status, nil
+}
@@ -0,0 +1,123 @@
+package evm
+
+import (
+	"fmt"
+	"math/big"
+)
+
+// RLPItem is a decoded RLP item: either a byte string or a list of items.
+// Raw holds the complete encoding of the item, which is needed to hash sub-structures.
+type RLPItem struct {
+	Bytes  []byte
+	List   []RLPItem
+	IsList bool
+	Raw    []byte
+}
+
+// DecodeRLP decodes a single RLP item that must span the whole input
+func DecodeRLP(data []byte) (RLPItem, error) {
+	item, rest, err := decodeRLPItem(data)
+	if err != nil {
+		return RLPItem{}, err
+	}
+	if len(rest) != 0 {
+		return RLPItem{}, fmt.Errorf("rlp: %d trailing bytes", len(rest))
+	}
+	return item, nil
+}
+
+// Uint interprets a byte string item as a big-endian unsigned integer
+func (item RLPItem) Uint() (*big.Int, error) {
+	if item.IsList {
+		return nil, fmt.Errorf("rlp: expected integer, got list")
+	}
+	if len(item.Bytes) > 32 {
+		return nil, fmt.Errorf("rlp: integer too large")
+	}
+	if len(item.Bytes) > 0 && item.Bytes[0] == 0 {
+		return nil, fmt.Errorf("rlp: non-canonical integer with leading zeros")
+	}
+	return new(big.Int).SetBytes(item.Bytes), nil
+}
+
+func decodeRLPItem(data []byte) (RLPItem, []byte, error) {
+	if len(data) == 0 {
+		return RLPItem{}, nil, fmt.Errorf("rlp: unexpected end of input")
+	}
+
+	prefix := data[0]
+	switch {
+	case prefix < 0x80:
+		return RLPItem{Bytes: data[:1], Raw: data[:1]}, data[1:], nil
+
+	case prefix < 0xb8:
+		if prefix == 0x81 && len(data) > 1 && data[1] < 0x80 {
+			return RLPItem{}, nil, fmt.Errorf("rlp: non-canonical single byte string")
+		}
+		return decodeRLPString(data, 1, uint64(prefix-0x80))
+
+	case prefix < 0xc0:
+		length, err := decodeRLPLength(data, int(prefix-0xb7))
+		if err != nil {
+			return RLPItem{}, nil, err
+		}
+		return decodeRLPString(data, 1+int(prefix-0xb7), length)
+
+	case prefix < 0xf8:
+		return decodeRLPList(data, 1, uint64(prefix-0xc0))
+
+	default:
+		length, err := decodeRLPLength(data, int(prefix-0xf7))
+		if err != nil {
+			return RLPItem{}, nil, err
+		}
+		return decodeRLPList(data, 1+int(prefix-0xf7), length)
+	}
+}
+
+// decodeRLPLength reads the big-endian length that follows a long-form prefix,
+// rejecting lengths with leading zeros or short enough for the short form
+func decodeRLPLength(data []byte, size int) (uint64, error) {
+	if size > 8 || len(data) < 1+size {
+		return 0, fmt.Errorf("rlp: invalid length prefix")
+	}
+	if data[1] == 0 {
+		return 0, fmt.Errorf("rlp: non-canonical length with leading zeros")
+	}
+	var length uint64
+	for _, b := range data[1 : 1+size] {
+		length = length<<8 | uint64(b)
+	}
+	if length < 56 {
+		return 0, fmt.Errorf("rlp: non-canonical long-form length %d", length)
+	}
+	return length, nil
+}
+
+func decodeRLPString(data []byte, headerSize int, length uint64) (RLPItem, []byte, error) {
+	// Compare against the remaining input rather than adding, which could overflow
+	if length > uint64(len(data)-headerSize) {
+		return RLPItem{}, nil, fmt.Errorf("rlp: string exceeds input")
+	}
+	end := headerSize + int(length)
+	return RLPItem{Bytes: data[headerSize:end], Raw: data[:end]}, data[end:], nil
+}
+
+func decodeRLPList(data []byte, headerSize int, length uint64) (RLPItem, []byte, error) {
+	if length > uint64(len(data)-headerSize) {
+		return RLPItem{}, nil, fmt.Errorf("rlp: list exceeds input")
+	}
+	end := headerSize + int(length)
+
+	item := RLPItem{IsList: true, Raw: data[:end]}
+	content := data[headerSize:end]
+	for len(content) > 0 {
+		child, rest, err := decodeRLPItem(content)
+		if err != nil {
+			return RLPItem{}, nil, err
+		}
+		item.List = append(item.List, child)
+		content = rest
+	}
+	return item, data[end:], nil
+}
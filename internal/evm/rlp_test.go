@@ -0,0 +1,131 @@
+package evm
+
+import (
+	"bytes"
+	"encoding/hex"
+	"strings"
+	"testing"
+)
+
+func TestDecodeRLP(t *testing.T) {
+	tests := []struct {
+		name    string
+		input   string
+		want    func(t *testing.T, item RLPItem)
+		wantErr string
+	}{
+		{
+			name:  "single byte",
+			input: "7f",
+			want: func(t *testing.T, item RLPItem) {
+				if item.IsList || !bytes.Equal(item.Bytes, []byte{0x7f}) {
+					t.Errorf("got %+v, want byte 0x7f", item)
+				}
+			},
+		},
+		{
+			name:  "empty string",
+			input: "80",
+			want: func(t *testing.T, item RLPItem) {
+				if item.IsList || len(item.Bytes) != 0 {
+					t.Errorf("got %+v, want empty string", item)
+				}
+			},
+		},
+		{
+			name:  "short string",
+			input: "83646f67",
+			want: func(t *testing.T, item RLPItem) {
+				if string(item.Bytes) != "dog" {
+					t.Errorf("got %q, want dog", item.Bytes)
+				}
+			},
+		},
+		{
+			name:  "long string",
+			input: "b838" + strings.Repeat("61", 56),
+			want: func(t *testing.T, item RLPItem) {
+				if len(item.Bytes) != 56 {
+					t.Errorf("got %d bytes, want 56", len(item.Bytes))
+				}
+			},
+		},
+		{
+			name:  "nested list",
+			input: "c8c3010203c3040506",
+			want: func(t *testing.T, item RLPItem) {
+				if !item.IsList || len(item.List) != 2 || len(item.List[1].List) != 3 {
+					t.Fatalf("got %+v, want two lists of three", item)
+				}
+				if hex.EncodeToString(item.List[1].Raw) != "c3040506" {
+					t.Errorf("got raw %x, want c3040506", item.List[1].Raw)
+				}
+			},
+		},
+		{name: "empty input", input: "", wantErr: "unexpected end of input"},
+		{name: "truncated string", input: "83646f", wantErr: "string exceeds input"},
+		{name: "truncated list", input: "c30102", wantErr: "list exceeds input"},
+		{name: "truncated list element", input: "c283646f", wantErr: "string exceeds input"},
+		{name: "truncated length prefix", input: "b9ff", wantErr: "invalid length prefix"},
+		{name: "overflowing string length", input: "bfffffffffffffffff00", wantErr: "string exceeds input"},
+		{name: "overflowing list length", input: "ffffffffffffffffff00", wantErr: "list exceeds input"},
+		{name: "trailing bytes", input: "8001", wantErr: "1 trailing bytes"},
+		{name: "non-canonical single byte", input: "8101", wantErr: "non-canonical single byte"},
+		{name: "non-canonical long form", input: "b803646f67", wantErr: "non-canonical long-form length"},
+		{name: "non-canonical length leading zeros", input: "b90038" + strings.Repeat("61", 56), wantErr: "leading zeros"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			input, err := hex.DecodeString(tt.input)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			item, err := DecodeRLP(input)
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("got error %v, want %q", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			tt.want(t, item)
+		})
+	}
+}
+
+func TestRLPItemUint(t *testing.T) {
+	tests := []struct {
+		name    string
+		item    RLPItem
+		want    string
+		wantErr string
+	}{
+		{name: "zero", item: RLPItem{}, want: "0"},
+		{name: "value", item: RLPItem{Bytes: []byte{0x04, 0x00}}, want: "1024"},
+		{name: "list", item: RLPItem{IsList: true}, wantErr: "got list"},
+		{name: "leading zeros", item: RLPItem{Bytes: []byte{0x00, 0x01}}, wantErr: "leading zeros"},
+		{name: "too large", item: RLPItem{Bytes: bytes.Repeat([]byte{0x01}, 33)}, wantErr: "too large"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			value, err := tt.item.Uint()
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("got error %v, want %q", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if value.String() != tt.want {
+				t.Errorf("got %s, want %s", value, tt.want)
+			}
+		})
+	}
+}
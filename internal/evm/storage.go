@@ -0,0 +1,181 @@
+// Package evm provides EVM helpers that don't need a node: hashing, storage slot arithmetic and transaction decoding.
+package evm
+
+import (
+	"encoding/hex"
+	"fmt"
+	"math/big"
+	"strings"
+
+	"golang.org/x/crypto/sha3"
+)
+
+// Well-known storage slots defined by proxy standards
+var WellKnownSlots = map[string]string{
+	// EIP-1967: bytes32(uint256(keccak256("eip1967.proxy.implementation")) - 1)
+	"eip1967.implementation": "0x360894a13ba1a3210667c828492db98dca3e2076cc3735a920a3ca505d382bbc",
+	// EIP-1967: bytes32(uint256(keccak256("eip1967.proxy.admin")) - 1)
+	"eip1967.admin": "0xb53127684a568b3173ae13b9f8a6016e243e63b6e8ee1178d6a717850b5d6103",
+	// EIP-1967: bytes32(uint256(keccak256("eip1967.proxy.beacon")) - 1)
+	"eip1967.beacon": "0xa3f0ad74e5423aebfd80d3ef4346578335a9a72aeaee59ff6cb3582b35133d50",
+	// EIP-1822 (UUPS): keccak256("PROXIABLE")
+	"eip1822.proxiable": "0xc5f16f0fcc639fa48a6947836d9850f504798523bf8c9a3a87d5876cf622bcf7",
+	// OpenZeppelin legacy (ZeppelinOS) proxies: keccak256("org.zeppelinos.proxy.implementation")
+	"zeppelinos.implementation": "0x7050c9e0f4ca769c69bd3a8ef740bc37934f8e2c036e5a723fd8ee048ed3f8c3",
+}
+
+// maxUint256 is used to wrap slot arithmetic modulo 2^256
+var maxUint256 = new(big.Int).Sub(new(big.Int).Lsh(big.NewInt(1), 256), big.NewInt(1))
+
+// Keccak256 returns the Keccak-256 hash used throughout Ethereum
+func Keccak256(data ...[]byte) []byte {
+	hasher := sha3.NewLegacyKeccak256()
+	for _, d := range data {
+		hasher.Write(d)
+	}
+	return hasher.Sum(nil)
+}
+
+// ParseSlot parses a storage slot given as a decimal number, a hex value or a well-known slot name
+func ParseSlot(slot string) (*big.Int, error) {
+	if named, ok := WellKnownSlots[strings.ToLower(slot)]; ok {
+		slot = named
+	}
+
+	value, ok := parseInt(slot)
+	if !ok || value.Sign() < 0 || value.Cmp(maxUint256) > 0 {
+		return nil, fmt.Errorf("invalid storage slot: %s", slot)
+	}
+	return value, nil
+}
+
+// FormatSlot formats a storage slot as a 0x-prefixed 32-byte hex string
+func FormatSlot(slot *big.Int) string {
+	return fmt.Sprintf("0x%064x", slot)
+}
+
+// MappingSlot computes the slot of mapping[key] for a mapping stored at baseSlot.
+// keyType is the Solidity type of the key (address, uintN, intN, bool, bytesN, string or bytes).
+func MappingSlot(baseSlot *big.Int, keyType, key string) (*big.Int, error) {
+	encodedKey, err := encodeMappingKey(keyType, key)
+	if err != nil {
+		return nil, err
+	}
+	hash := Keccak256(encodedKey, word(baseSlot))
+	return new(big.Int).SetBytes(hash), nil
+}
+
+// DynamicArraySlot computes the slot of array[index] for a dynamic array stored at baseSlot,
+// where each element occupies elementSlots slots
+func DynamicArraySlot(baseSlot, index *big.Int, elementSlots int64) *big.Int {
+	start := new(big.Int).SetBytes(Keccak256(word(baseSlot)))
+	return AddSlot(start, new(big.Int).Mul(index, big.NewInt(elementSlots)))
+}
+
+// AddSlot adds an offset to a slot, wrapping modulo 2^256
+func AddSlot(slot, offset *big.Int) *big.Int {
+	return new(big.Int).And(new(big.Int).Add(slot, offset), maxUint256)
+}
+
+// AddressFromWord extracts an address from a 32-byte storage word, returning false if the
+// upper 12 bytes are not zero or the word is empty
+func AddressFromWord(value string) (string, bool) {
+	raw := strings.TrimPrefix(strings.ToLower(value), "0x")
+	if len(raw) != 64 || strings.Trim(raw, "0") == "" {
+		return "", false
+	}
+	if strings.Trim(raw[:24], "0") != "" {
+		return "", false
+	}
+	return "0x" + raw[24:], true
+}
+
+// encodeMappingKey encodes a mapping key the way Solidity does before hashing it with the slot
+func encodeMappingKey(keyType, key string) ([]byte, error) {
+	keyType = strings.TrimSpace(keyType)
+	switch {
+	case keyType == "address":
+		raw := strings.TrimPrefix(strings.ToLower(key), "0x")
+		if len(raw) != 40 {
+			return nil, fmt.Errorf("invalid address key: %s", key)
+		}
+		value, ok := new(big.Int).SetString(raw, 16)
+		if !ok {
+			return nil, fmt.Errorf("invalid address key: %s", key)
+		}
+		return word(value), nil
+
+	case keyType == "bool":
+		switch key {
+		case "true", "1":
+			return word(big.NewInt(1)), nil
+		case "false", "0":
+			return word(big.NewInt(0)), nil
+		}
+		return nil, fmt.Errorf("invalid bool key: %s", key)
+
+	case strings.HasPrefix(keyType, "uint"):
+		value, ok := parseInt(key)
+		if !ok || value.Sign() < 0 || value.Cmp(maxUint256) > 0 {
+			return nil, fmt.Errorf("invalid %s key: %s", keyType, key)
+		}
+		return word(value), nil
+
+	case strings.HasPrefix(keyType, "int"):
+		value, ok := parseInt(key)
+		if !ok {
+			return nil, fmt.Errorf("invalid %s key: %s", keyType, key)
+		}
+		if value.Sign() < 0 {
+			// Two's complement representation in 256 bits
+			value = new(big.Int).Add(value, new(big.Int).Lsh(big.NewInt(1), 256))
+		}
+		if value.Sign() < 0 || value.Cmp(maxUint256) > 0 {
+			return nil, fmt.Errorf("invalid %s key: %s", keyType, key)
+		}
+		return word(value), nil
+
+	case keyType == "string":
+		return []byte(key), nil
+
+	case keyType == "bytes":
+		return decodeHex(key)
+
+	case strings.HasPrefix(keyType, "bytes"):
+		// Fixed-size bytesN keys are left-aligned in the word
+		data, err := decodeHex(key)
+		if err != nil || len(data) > 32 {
+			return nil, fmt.Errorf("invalid %s key: %s", keyType, key)
+		}
+		padded := make([]byte, 32)
+		copy(padded, data)
+		return padded, nil
+	}
+
+	return nil, fmt.Errorf("unsupported mapping key type: %s", keyType)
+}
+
+// word left-pads a non-negative integer to a 32-byte big-endian word
+func word(value *big.Int) []byte {
+	padded := make([]byte, 32)
+	value.FillBytes(padded)
+	return padded
+}
+
+// parseInt parses a decimal or 0x-prefixed hex integer
+func parseInt(value string) (*big.Int, bool) {
+	value = strings.TrimSpace(value)
+	if strings.HasPrefix(value, "0x") || strings.HasPrefix(value, "0X") {
+		return new(big.Int).SetString(value[2:], 16)
+	}
+	return new(big.Int).SetString(value, 10)
+}
+
+// decodeHex decodes a hex string with or without the 0x prefix
+func decodeHex(value string) ([]byte, error) {
+	value = strings.TrimPrefix(strings.TrimPrefix(value, "0x"), "0X")
+	if len(value)%2 == 1 {
+		value = "0" + value
+	}
+	return hex.DecodeString(value)
+}
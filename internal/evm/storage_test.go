@@ -0,0 +1,207 @@
+package evm
+
+import (
+	"encoding/hex"
+	"math/big"
+	"strings"
+	"testing"
+)
+
+func TestWellKnownSlots(t *testing.T) {
+	// EIP-1967 slots are keccak256(label) - 1; the others are plain hashes
+	tests := []struct {
+		name     string
+		preimage string
+		minusOne bool
+	}{
+		{"eip1967.implementation", "eip1967.proxy.implementation", true},
+		{"eip1967.admin", "eip1967.proxy.admin", true},
+		{"eip1967.beacon", "eip1967.proxy.beacon", true},
+		{"eip1822.proxiable", "PROXIABLE", false},
+		{"zeppelinos.implementation", "org.zeppelinos.proxy.implementation", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			want := new(big.Int).SetBytes(Keccak256([]byte(tt.preimage)))
+			if tt.minusOne {
+				want.Sub(want, big.NewInt(1))
+			}
+			slot, err := ParseSlot(tt.name)
+			if err != nil {
+				t.Fatalf("ParseSlot() error = %v", err)
+			}
+			if slot.Cmp(want) != 0 {
+				t.Errorf("got %s, want %s", FormatSlot(slot), FormatSlot(want))
+			}
+		})
+	}
+}
+
+func TestKeccak256(t *testing.T) {
+	tests := []struct {
+		input string
+		want  string
+	}{
+		{"", "c5d2460186f7233c927e7db2dcc703c0e500b653ca82273b7bfad8045d85a470"},
+		{strings.Repeat("00", 32), "290decd9548b62a8d60345a988386fc84ba6bc95484008f6362f93160ef3e563"},
+	}
+
+	for _, tt := range tests {
+		input, _ := hex.DecodeString(tt.input)
+		if got := hex.EncodeToString(Keccak256(input)); got != tt.want {
+			t.Errorf("Keccak256(%q) = %s, want %s", tt.input, got, tt.want)
+		}
+	}
+}
+
+func TestMappingSlot(t *testing.T) {
+	tests := []struct {
+		name    string
+		base    int64
+		keyType string
+		key     string
+		want    string
+		wantErr string
+	}{
+		{
+			name:    "address key at slot 0",
+			keyType: "address",
+			key:     "0xd8dA6BF26964aF9D7eEd9e03E53415D37aA96045",
+			want:    "0xfca351f4d96129454cfc8ef7930b638ac71fea35eb69ee3b8d959496beb04a33",
+		},
+		{
+			name:    "address key at slot 3",
+			base:    3,
+			keyType: "address",
+			key:     "d8da6bf26964af9d7eed9e03e53415d37aa96045",
+			want:    "0x3a988d762a24303c37d08f1543db6143453b579691d5c20fed39629ff1334cca",
+		},
+		{
+			name:    "uint key",
+			base:    1,
+			keyType: "uint256",
+			key:     "7",
+			want:    "0xdc686ec4a0ff239c70e7c7c36e8f853eced3bc8618f48d2b816da2a74311237e",
+		},
+		{
+			name:    "hex uint key",
+			base:    1,
+			keyType: "uint8",
+			key:     "0x07",
+			want:    "0xdc686ec4a0ff239c70e7c7c36e8f853eced3bc8618f48d2b816da2a74311237e",
+		},
+		{
+			name:    "negative int key",
+			keyType: "int256",
+			key:     "-1",
+			want:    "0xbbd6e7dddd4326dd7c827841ab9733c6e3fcdf38a516374bd10feec8f674ea8a",
+		},
+		{
+			name:    "string key is hashed unpadded",
+			base:    2,
+			keyType: "string",
+			key:     "hello",
+			want:    "0x98cc3604479d1233834ea19a78b22cff641ec62dc88921ba3f1f66a37957a4f8",
+		},
+		{
+			name:    "bytes4 key is left-aligned",
+			base:    5,
+			keyType: "bytes4",
+			key:     "0xa9059cbb",
+			want:    "0xc380f7d569119f698e22840bb93ec0f170b05f88909772744653f081be577628",
+		},
+		{name: "short address", keyType: "address", key: "0x1234", wantErr: "invalid address key"},
+		{name: "negative uint", keyType: "uint256", key: "-1", wantErr: "invalid uint256 key"},
+		{name: "invalid bool", keyType: "bool", key: "yes", wantErr: "invalid bool key"},
+		{name: "unsupported type", keyType: "tuple", key: "1", wantErr: "unsupported mapping key type"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			slot, err := MappingSlot(big.NewInt(tt.base), tt.keyType, tt.key)
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("MappingSlot() error = %v, want %q", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("MappingSlot() error = %v", err)
+			}
+			if got := FormatSlot(slot); got != tt.want {
+				t.Errorf("got %s, want %s", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestArraySlots(t *testing.T) {
+	maxSlot, _ := ParseSlot("0x" + strings.Repeat("f", 64))
+
+	tests := []struct {
+		name string
+		got  *big.Int
+		want string
+	}{
+		{
+			name: "first element of a dynamic array at slot 0",
+			got:  DynamicArraySlot(big.NewInt(0), big.NewInt(0), 1),
+			want: "0x290decd9548b62a8d60345a988386fc84ba6bc95484008f6362f93160ef3e563",
+		},
+		{
+			name: "element 3 of two-slot elements at slot 2",
+			got:  DynamicArraySlot(big.NewInt(2), big.NewInt(3), 2),
+			want: "0x405787fa12a823e0f2b7631cc41b3ba8828b3321ca811111fa75cd3aa3bb5ad4",
+		},
+		{
+			name: "struct member offset",
+			got:  AddSlot(big.NewInt(5), big.NewInt(2)),
+			want: FormatSlot(big.NewInt(7)),
+		},
+		{
+			name: "offset wraps modulo 2^256",
+			got:  AddSlot(maxSlot, big.NewInt(2)),
+			want: FormatSlot(big.NewInt(1)),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := FormatSlot(tt.got); got != tt.want {
+				t.Errorf("got %s, want %s", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParseSlot(t *testing.T) {
+	tests := []struct {
+		input   string
+		want    int64
+		wantErr bool
+	}{
+		{input: "0", want: 0},
+		{input: "10", want: 10},
+		{input: "010", want: 10},
+		{input: "0x10", want: 16},
+		{input: "-1", wantErr: true},
+		{input: "0x1" + strings.Repeat("0", 64), wantErr: true},
+		{input: "slot", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			slot, err := ParseSlot(tt.input)
+			if tt.wantErr {
+				if err == nil {
+					t.Fatalf("ParseSlot(%q) = %s, want an error", tt.input, slot)
+				}
+				return
+			}
+			if err != nil || slot.Int64() != tt.want {
+				t.Errorf("ParseSlot(%q) = %v, %v, want %d", tt.input, slot, err, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,186 @@
+package evm
+
+import (
+	"encoding/hex"
+	"fmt"
+	"math/big"
+)
+
+// Transaction types defined by EIP-2718 envelopes
+const (
+	LegacyTxType     = 0
+	AccessListTxType = 1
+	DynamicFeeTxType = 2
+	BlobTxType       = 3
+	SetCodeTxType    = 4
+)
+
+// Transaction is a decoded signed transaction. Fields that don't apply to the type are nil.
+type Transaction struct {
+	Type                 int
+	Hash                 string
+	ChainID              *big.Int // nil for legacy transactions without EIP-155 replay protection
+	Nonce                *big.Int
+	To                   string // empty for contract creation
+	Value                *big.Int
+	Gas                  *big.Int
+	GasPrice             *big.Int
+	MaxFeePerGas         *big.Int
+	MaxPriorityFeePerGas *big.Int
+	MaxFeePerBlobGas     *big.Int
+	Data                 []byte
+	AccessListSize       int
+	BlobHashes           []string
+	AuthorizationCount   int
+}
+
+// typedTxFields is the number of list elements of each typed transaction payload
+var typedTxFields = map[int]int{
+	AccessListTxType: 11,
+	DynamicFeeTxType: 12,
+	BlobTxType:       14,
+	SetCodeTxType:    13,
+}
+
+// DecodeTransaction decodes a signed raw transaction as accepted by eth_sendRawTransaction.
+// Blob transactions may be given in their network form with blobs, commitments and proofs attached.
+func DecodeTransaction(raw []byte) (*Transaction, error) {
+	if len(raw) == 0 {
+		return nil, fmt.Errorf("empty transaction")
+	}
+
+	if raw[0] >= 0xc0 {
+		return decodeLegacyTransaction(raw)
+	}
+
+	txType := int(raw[0])
+	fieldCount, ok := typedTxFields[txType]
+	if !ok {
+		return nil, fmt.Errorf("unsupported transaction type %d", txType)
+	}
+
+	payload, err := DecodeRLP(raw[1:])
+	if err != nil {
+		return nil, fmt.Errorf("invalid transaction encoding: %w", err)
+	}
+	if txType == BlobTxType && payload.IsList && len(payload.List) > 0 && payload.List[0].IsList {
+		// Network form: the first element is the transaction itself
+		payload = payload.List[0]
+	}
+	if !payload.IsList || len(payload.List) != fieldCount {
+		return nil, fmt.Errorf("invalid type %d transaction: expected %d fields", txType, fieldCount)
+	}
+
+	tx := &Transaction{
+		Type: txType,
+		Hash: "0x" + hex.EncodeToString(Keccak256([]byte{raw[0]}, payload.Raw)),
+	}
+	fields := payload.List
+
+	// Leading integer fields: chainId, nonce, fee fields and gas
+	leading := []**big.Int{&tx.ChainID, &tx.Nonce, &tx.MaxPriorityFeePerGas, &tx.MaxFeePerGas, &tx.Gas}
+	if txType == AccessListTxType {
+		leading = []**big.Int{&tx.ChainID, &tx.Nonce, &tx.GasPrice, &tx.Gas}
+	}
+	if err := decodeUints(fields, leading); err != nil {
+		return nil, err
+	}
+	fields = fields[len(leading):]
+
+	// Remaining common fields: to, value, data, accessList
+	if err := tx.decodeCallFields(fields[0], fields[1], fields[2]); err != nil {
+		return nil, err
+	}
+	if !fields[3].IsList {
+		return nil, fmt.Errorf("invalid access list")
+	}
+	tx.AccessListSize = len(fields[3].List)
+
+	switch txType {
+	case BlobTxType:
+		if tx.MaxFeePerBlobGas, err = fields[4].Uint(); err != nil {
+			return nil, fmt.Errorf("invalid maxFeePerBlobGas: %w", err)
+		}
+		if !fields[5].IsList {
+			return nil, fmt.Errorf("invalid blob versioned hashes")
+		}
+		for _, blobHash := range fields[5].List {
+			tx.BlobHashes = append(tx.BlobHashes, "0x"+hex.EncodeToString(blobHash.Bytes))
+		}
+	case SetCodeTxType:
+		if !fields[4].IsList {
+			return nil, fmt.Errorf("invalid authorization list")
+		}
+		tx.AuthorizationCount = len(fields[4].List)
+	}
+
+	return tx, nil
+}
+
+// decodeLegacyTransaction decodes an untyped transaction, deriving the chain ID from v (EIP-155)
+func decodeLegacyTransaction(raw []byte) (*Transaction, error) {
+	payload, err := DecodeRLP(raw)
+	if err != nil {
+		return nil, fmt.Errorf("invalid transaction encoding: %w", err)
+	}
+	if !payload.IsList || len(payload.List) != 9 {
+		return nil, fmt.Errorf("invalid legacy transaction: expected 9 fields")
+	}
+
+	tx := &Transaction{
+		Type: LegacyTxType,
+		Hash: "0x" + hex.EncodeToString(Keccak256(raw)),
+	}
+	fields := payload.List
+
+	if err := decodeUints(fields, []**big.Int{&tx.Nonce, &tx.GasPrice, &tx.Gas}); err != nil {
+		return nil, err
+	}
+	if err := tx.decodeCallFields(fields[3], fields[4], fields[5]); err != nil {
+		return nil, err
+	}
+
+	v, err := fields[6].Uint()
+	if err != nil {
+		return nil, fmt.Errorf("invalid signature v: %w", err)
+	}
+	if v.Cmp(big.NewInt(35)) >= 0 {
+		// v = chainId * 2 + 35 + yParity
+		tx.ChainID = new(big.Int).Rsh(new(big.Int).Sub(v, big.NewInt(35)), 1)
+	}
+
+	return tx, nil
+}
+
+// decodeCallFields decodes the to, value and data fields shared by all transaction types
+func (tx *Transaction) decodeCallFields(to, value, data RLPItem) error {
+	if to.IsList || (len(to.Bytes) != 0 && len(to.Bytes) != 20) {
+		return fmt.Errorf("invalid recipient address")
+	}
+	if len(to.Bytes) == 20 {
+		tx.To = "0x" + hex.EncodeToString(to.Bytes)
+	}
+
+	var err error
+	if tx.Value, err = value.Uint(); err != nil {
+		return fmt.Errorf("invalid value: %w", err)
+	}
+
+	if data.IsList {
+		return fmt.Errorf("invalid input data")
+	}
+	tx.Data = data.Bytes
+	return nil
+}
+
+// decodeUints decodes the leading integer fields of a transaction into targets
+func decodeUints(fields []RLPItem, targets []**big.Int) error {
+	for i, target := range targets {
+		value, err := fields[i].Uint()
+		if err != nil {
+			return fmt.Errorf("invalid transaction field %d: %w", i, err)
+		}
+		*target = value
+	}
+	return nil
+}
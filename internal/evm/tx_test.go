@@ -0,0 +1,123 @@
+package evm
+
+import (
+	"encoding/hex"
+	"strconv"
+	"strings"
+	"testing"
+)
+
+func TestDecodeTransaction(t *testing.T) {
+	tests := []struct {
+		name    string
+		raw     string
+		want    func(t *testing.T, tx *Transaction)
+		wantErr string
+	}{
+		{
+			// The example transaction from EIP-155
+			name: "legacy EIP-155",
+			raw:  "f86c098504a817c800825208943535353535353535353535353535353535353535880de0b6b3a76400008025a028ef61340bd939bc2195fe537567866003e1a15d3c71ff63e1590620aa636276a067cbe9d8997f761aecb703304b3800ccf555c9f3dc64214b297fb1966a3b6d83",
+			want: func(t *testing.T, tx *Transaction) {
+				checkFields(t, map[string][2]string{
+					"type":     {strconv.Itoa(tx.Type), "0"},
+					"hash":     {tx.Hash, "0x33469b22e9f636356c4160a87eb19df52b7412e8eac32a4a55ffe88ea8350788"},
+					"chainId":  {tx.ChainID.String(), "1"},
+					"nonce":    {tx.Nonce.String(), "9"},
+					"gasPrice": {tx.GasPrice.String(), "20000000000"},
+					"gas":      {tx.Gas.String(), "21000"},
+					"to":       {tx.To, "0x3535353535353535353535353535353535353535"},
+					"value":    {tx.Value.String(), "1000000000000000000"},
+				})
+			},
+		},
+		{
+			name: "legacy contract creation without replay protection",
+			raw:  "d0800182cf0880808560806040521b0101",
+			want: func(t *testing.T, tx *Transaction) {
+				if tx.ChainID != nil {
+					t.Errorf("got chainId %s, want none", tx.ChainID)
+				}
+				if tx.To != "" {
+					t.Errorf("got to %s, want contract creation", tx.To)
+				}
+				if hex.EncodeToString(tx.Data) != "6080604052" {
+					t.Errorf("got data %x, want 6080604052", tx.Data)
+				}
+			},
+		},
+		{
+			name: "dynamic fee",
+			raw:  "02f86a0107843b9aca008504a817c80082520894111111111111111111111111111111111111111182303984a9059cbbf838f7941111111111111111111111111111111111111111e1a00000000000000000000000000000000000000000000000000000000000000000010101",
+			want: func(t *testing.T, tx *Transaction) {
+				checkFields(t, map[string][2]string{
+					"type":                 {strconv.Itoa(tx.Type), "2"},
+					"chainId":              {tx.ChainID.String(), "1"},
+					"nonce":                {tx.Nonce.String(), "7"},
+					"maxPriorityFeePerGas": {tx.MaxPriorityFeePerGas.String(), "1000000000"},
+					"maxFeePerGas":         {tx.MaxFeePerGas.String(), "20000000000"},
+					"gas":                  {tx.Gas.String(), "21000"},
+					"to":                   {tx.To, "0x1111111111111111111111111111111111111111"},
+					"value":                {tx.Value.String(), "12345"},
+					"data":                 {hex.EncodeToString(tx.Data), "a9059cbb"},
+					"accessListSize":       {strconv.Itoa(tx.AccessListSize), "1"},
+				})
+			},
+		},
+		{
+			name: "blob",
+			raw:  "03f84605800102830186a09411111111111111111111111111111111111111118080c003e1a00122222222222222222222222222222222222222222222222222222222222222800101",
+			want: func(t *testing.T, tx *Transaction) {
+				checkFields(t, map[string][2]string{
+					"type":             {strconv.Itoa(tx.Type), "3"},
+					"chainId":          {tx.ChainID.String(), "5"},
+					"maxFeePerBlobGas": {tx.MaxFeePerBlobGas.String(), "3"},
+					"blobHashes":       {strconv.Itoa(len(tx.BlobHashes)), "1"},
+				})
+				if len(tx.BlobHashes) == 1 && !strings.HasPrefix(tx.BlobHashes[0], "0x0122") {
+					t.Errorf("got blob hash %s, want 0x0122...", tx.BlobHashes[0])
+				}
+			},
+		},
+		{name: "empty", raw: "", wantErr: "empty transaction"},
+		{name: "unsupported type", raw: "05c0", wantErr: "unsupported transaction type 5"},
+		{name: "truncated legacy", raw: "f86c098504a817c800", wantErr: "invalid transaction encoding"},
+		{name: "truncated typed", raw: "02f86a0107843b9aca00", wantErr: "invalid transaction encoding"},
+		{name: "overflowing length", raw: "02bfffffffffffffffff00", wantErr: "invalid transaction encoding"},
+		{name: "wrong field count", raw: "02c3010203", wantErr: "expected 12 fields"},
+		{name: "legacy wrong field count", raw: "c3010203", wantErr: "expected 9 fields"},
+		{name: "non-canonical single byte nonce", raw: "d181010182cf0880808560806040521b0101", wantErr: "non-canonical single byte"},
+		{name: "nonce with leading zeros", raw: "d28200010182cf0880808560806040521b0101", wantErr: "leading zeros"},
+		{name: "invalid recipient", raw: "cc800182cf0881ff80801b0101", wantErr: "invalid recipient address"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			raw, err := hex.DecodeString(tt.raw)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			tx, err := DecodeTransaction(raw)
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("got error %v, want %q", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			tt.want(t, tx)
+		})
+	}
+}
+
+func checkFields(t *testing.T, fields map[string][2]string) {
+	t.Helper()
+	for name, field := range fields {
+		if field[0] != field[1] {
+			t.Errorf("%s: got %s, want %s", name, field[0], field[1])
+		}
+	}
+}
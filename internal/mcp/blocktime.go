@@ -0,0 +1,101 @@
+package mcp
+
+import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+
+	"github.com/huahuayu/etherscan-mcp-server/internal/chains"
+	"github.com/huahuayu/etherscan-mcp-server/internal/etherscan"
+	"github.com/huahuayu/etherscan-mcp-server/internal/rpc"
+)
+
+// blockTimeSampleSize is how many blocks back from the head are used to measure the average block time
+const blockTimeSampleSize = 10000
+
+// blockHeader holds the fields of a block needed for time estimation
+type blockHeader struct {
+	Number    uint64
+	Timestamp int64
+}
+
+// blockTimeStats describes the recent block production rate of a chain
+type blockTimeStats struct {
+	Latest           blockHeader
+	AverageBlockTime float64 // seconds per block
+}
+
+// fetchBlockHeader gets the number and timestamp of a block from the chain's backend
+func fetchBlockHeader(client etherscan.Explorer, rpcClient *rpc.Client, chainID, blockNumber string) (blockHeader, error) {
+	result, _, err := fetch(chainID,
+		func() (json.RawMessage, error) { return client.GetBlockByNumber(chainID, blockNumber, false) },
+		func() (json.RawMessage, error) { return rpcClient.GetBlockByNumber(chainID, blockNumber, false) })
+	if err != nil {
+		return blockHeader{}, err
+	}
+
+	var block struct {
+		Number    string `json:"number"`
+		Timestamp string `json:"timestamp"`
+	}
+	if err := json.Unmarshal(result, &block); err != nil || block.Number == "" {
+		return blockHeader{}, fmt.Errorf("block %s not found", blockNumber)
+	}
+
+	number, err := strconv.ParseUint(block.Number, 0, 64)
+	if err != nil {
+		return blockHeader{}, fmt.Errorf("failed to parse block number %q: %w", block.Number, err)
+	}
+	timestamp, err := strconv.ParseInt(block.Timestamp, 0, 64)
+	if err != nil {
+		return blockHeader{}, fmt.Errorf("failed to parse block timestamp %q: %w", block.Timestamp, err)
+	}
+
+	return blockHeader{Number: number, Timestamp: timestamp}, nil
+}
+
+// measureBlockTime computes the average block time over the most recent blocks of a chain
+func measureBlockTime(client etherscan.Explorer, rpcClient *rpc.Client, chainID string) (blockTimeStats, error) {
+	latest, err := fetchBlockHeader(client, rpcClient, chainID, "latest")
+	if err != nil {
+		return blockTimeStats{}, fmt.Errorf("failed to get latest block: %w", err)
+	}
+
+	sampleSize := uint64(blockTimeSampleSize)
+	if latest.Number < sampleSize {
+		sampleSize = latest.Number
+	}
+	if sampleSize == 0 {
+		if stats, ok := registryBlockTime(chainID, latest); ok {
+			return stats, nil
+		}
+		return blockTimeStats{}, fmt.Errorf("not enough blocks on chain %s to measure block time", chainID)
+	}
+
+	past, err := fetchBlockHeader(client, rpcClient, chainID, strconv.FormatUint(latest.Number-sampleSize, 10))
+	if err != nil {
+		return blockTimeStats{}, fmt.Errorf("failed to get reference block: %w", err)
+	}
+
+	elapsed := latest.Timestamp - past.Timestamp
+	if elapsed <= 0 {
+		if stats, ok := registryBlockTime(chainID, latest); ok {
+			return stats, nil
+		}
+		return blockTimeStats{}, fmt.Errorf("unable to measure block time on chain %s", chainID)
+	}
+
+	return blockTimeStats{
+		Latest:           latest,
+		AverageBlockTime: float64(elapsed) / float64(latest.Number-past.Number),
+	}, nil
+}
+
+// registryBlockTime uses the expected block time from the chain registry when it can't be measured
+func registryBlockTime(chainID string, latest blockHeader) (blockTimeStats, bool) {
+	chain, ok := chains.Get(chainID)
+	if !ok || chain.BlockTime <= 0 {
+		return blockTimeStats{}, false
+	}
+	return blockTimeStats{Latest: latest, AverageBlockTime: chain.BlockTime}, true
+}
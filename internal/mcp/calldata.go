@@ -0,0 +1,171 @@
+package mcp
+
+import (
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/huahuayu/etherscan-mcp-server/internal/abi"
+	"github.com/huahuayu/etherscan-mcp-server/internal/etherscan"
+)
+
+// abiCacheTTL is how long ABIs are cached; a proxy's merged ABI changes when it is upgraded,
+// and an unverified contract may be verified later
+const abiCacheTTL = time.Hour
+
+// abiCache caches parsed verified ABIs per chain and address; unverified contracts are cached as nil
+type abiCache struct {
+	mu      sync.Mutex
+	entries map[string]abiEntry
+}
+
+type abiEntry struct {
+	abi     *abi.ABI
+	expires time.Time
+}
+
+var contractABIs = &abiCache{entries: make(map[string]abiEntry)}
+
+// lookup returns the verified ABI of a contract, merged with its implementation's ABI for proxies.
+// Failed lookups return nil without being cached, so a rate limit or timeout doesn't stick.
+func (c *abiCache) lookup(client etherscan.Explorer, chainID, address string) *abi.ABI {
+	if parsed, ok := c.cached(chainID, address); ok {
+		return parsed
+	}
+
+	parsed, err := fetchABI(client, chainID, address)
+	if err != nil {
+		return nil
+	}
+	if parsed != nil {
+		bundle, err := client.GetContractSourceBundle(chainID, address)
+		if err != nil {
+			return parsed
+		}
+		if bundle.Proxy && bundle.Implementation != "" {
+			implementation, err := fetchABI(client, chainID, bundle.Implementation)
+			if err != nil {
+				return parsed
+			}
+			if implementation != nil {
+				parsed.Merge(implementation)
+			}
+		}
+	}
+
+	c.mu.Lock()
+	c.entries[chainID+":"+strings.ToLower(address)] = abiEntry{abi: parsed, expires: time.Now().Add(abiCacheTTL)}
+	c.mu.Unlock()
+
+	return parsed
+}
+
+// cached returns the cached ABI of a contract without fetching it
+func (c *abiCache) cached(chainID, address string) (*abi.ABI, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	entry, ok := c.entries[chainID+":"+strings.ToLower(address)]
+	if !ok || time.Now().After(entry.expires) {
+		return nil, false
+	}
+	return entry.abi, true
+}
+
+// fetchABI gets and parses the verified ABI of a contract. It returns nil without an error
+// when the contract is not verified or its ABI can't be parsed, and an error when the lookup failed.
+func fetchABI(client etherscan.Explorer, chainID, address string) (*abi.ABI, error) {
+	abiJSON, err := client.GetContractABI(chainID, address)
+	if etherscan.IsNotVerifiedError(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	parsed, err := abi.Parse(abiJSON)
+	if err != nil {
+		return nil, nil
+	}
+	return parsed, nil
+}
+
+// methodResolver finds the methods of many calls on one chain, capping the uncached ABI lookups
+type methodResolver struct {
+	client  etherscan.Explorer
+	chainID string
+	lookups int
+}
+
+// resolve finds the method called by data, preferring the target's verified ABI over common signatures
+func (r *methodResolver) resolve(to string, data []byte) (*abi.Method, string) {
+	if len(data) < 4 {
+		return nil, ""
+	}
+	if parsed := r.contractABI(to); parsed != nil {
+		if method, ok := parsed.MethodByData(data); ok {
+			return method, "verified"
+		}
+	}
+	if method, ok := abi.Common.MethodByData(data); ok {
+		return method, "common"
+	}
+	return nil, ""
+}
+
+// resolveEvent finds the event of a log, preferring the emitter's verified ABI over common signatures
+func (r *methodResolver) resolveEvent(address string, topics []string) (*abi.Event, string) {
+	if parsed := r.contractABI(address); parsed != nil {
+		if event, ok := parsed.EventByTopics(topics); ok {
+			return event, "verified"
+		}
+	}
+	if event, ok := abi.Common.EventByTopics(topics); ok {
+		return event, "common"
+	}
+	return nil, ""
+}
+
+// contractABI returns the verified ABI of a contract while lookups remain, or a cached one
+func (r *methodResolver) contractABI(address string) *abi.ABI {
+	if address == "" {
+		return nil
+	}
+	parsed, ok := contractABIs.cached(r.chainID, address)
+	if !ok && r.lookups > 0 {
+		r.lookups--
+		parsed = contractABIs.lookup(r.client, r.chainID, address)
+	}
+	return parsed
+}
+
+// findMethod finds the method called by a single call
+func findMethod(client etherscan.Explorer, chainID, to string, data []byte) (*abi.Method, string) {
+	resolver := &methodResolver{client: client, chainID: chainID, lookups: 1}
+	return resolver.resolve(to, data)
+}
+
+// decodeCalldata describes call data: its selector and, when the method is known, the decoded arguments
+func decodeCalldata(client etherscan.Explorer, chainID, to string, data []byte) map[string]interface{} {
+	if len(data) < 4 {
+		return nil
+	}
+
+	decoded := map[string]interface{}{
+		"selector": abi.Selector(data),
+	}
+
+	method, source := findMethod(client, chainID, to, data)
+	if method == nil {
+		return decoded
+	}
+
+	decoded["method"] = method.Name
+	decoded["signature"] = method.Signature
+	decoded["abiSource"] = source
+	if arguments, err := method.DecodeInput(data); err == nil {
+		decoded["arguments"] = arguments
+	} else {
+		decoded["decodeError"] = err.Error()
+	}
+
+	return decoded
+}
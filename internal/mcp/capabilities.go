@@ -0,0 +1,177 @@
+package mcp
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+
+	"github.com/huahuayu/etherscan-mcp-server/internal/chains"
+	"github.com/huahuayu/etherscan-mcp-server/internal/etherscan"
+	"github.com/huahuayu/etherscan-mcp-server/internal/rpc"
+	"github.com/mark3labs/mcp-go/mcp"
+)
+
+// rpcOnlyTools are served by JSON-RPC alone and need an RPC endpoint for the chain
+var rpcOnlyTools = []string{"getBlockByHash", "getFeeHistory", "simulateTransaction", "getTransactionTrace", "getStateDiff"}
+
+// rpcCapableTools can answer without Etherscan, from JSON-RPC or local computation. Tools that
+// enrich their output from Etherscan (ABIs, labels, prices) answer without the enrichment.
+var rpcCapableTools = keySet("getAccountBalance", "getBlockByNumber", "getBlockByHash", "getLatestBlockNumber",
+	"getBlockCountdown", "estimateBlockByTimestamp", "getTokenBalance", "getTokenDetails", "getTokenSupply",
+	"executeContractMethod", "getContractCode", "getStorageAt", "computeStorageSlot", "resolveProxy",
+	"getTransactionByHash", "getTransactionByBlockNumberAndIndex", "getTransactionReceipt", "getTransactionCount",
+	"getLogs", "getGasPrice", "getGasOracle", "getFeeHistory", "estimateGas", "simulateTransaction",
+	"getTransactionTrace", "getStateDiff", "explainTransaction", "sendRawTransaction", "listChains",
+	"getChainCapabilities")
+
+// proxyModuleTools map the tools that reach the explorer through its proxy module to the action they
+// call; explorers without a proxy module leave them to RPC
+var proxyModuleTools = map[string]string{
+	"getBlockByNumber":                    "eth_getBlockByNumber",
+	"getTransactionByHash":                "eth_getTransactionByHash",
+	"getTransactionByBlockNumberAndIndex": "eth_getTransactionByBlockNumberAndIndex",
+	"getTransactionReceipt":               "eth_getTransactionReceipt",
+	"getTransactionCount":                 "eth_getTransactionCount",
+	"executeContractMethod":               "eth_call",
+	"getContractCode":                     "eth_getCode",
+	"resolveProxy":                        "eth_getCode",
+	"getStorageAt":                        "eth_getStorageAt",
+	"getGasPrice":                         "eth_gasPrice",
+	"estimateGas":                         "eth_estimateGas",
+	"explainTransaction":                  "eth_getTransactionByHash",
+	"sendRawTransaction":                  "eth_sendRawTransaction",
+}
+
+// offlineTools answer from the server's own data and need no backend at all
+var offlineTools = keySet("computeStorageSlot", "listChains", "getChainCapabilities")
+
+var (
+	// rpcOnlyMode is set when no Etherscan API key is configured
+	rpcOnlyMode bool
+	// disabledTools are the tools left unregistered in RPC-only mode
+	disabledTools []string
+)
+
+// EnableRPCOnlyMode serves every chain from its RPC endpoint and registers only the tools that can
+// answer without Etherscan. It must be called before the tools are registered.
+func EnableRPCOnlyMode() {
+	rpcOnlyMode = true
+	defaultStrategy = strategyRPCOnly
+}
+
+// DisabledTools returns the tools left unregistered because they need Etherscan
+func DisabledTools() []string {
+	return uniqueSorted(disabledTools)
+}
+
+// chainSpecificTools returns the tools backed by endpoints that only some chains serve, and that the
+// explorer serving this chain offers
+func chainSpecificTools(client etherscan.Explorer, chainID string) []string {
+	var tools []string
+	for _, endpoint := range etherscan.Endpoints() {
+		if len(endpoint.Only) > 0 && client.Availability(chainID, endpoint.Module, endpoint.Action) == etherscan.Supported {
+			tools = append(tools, endpoint.Tools...)
+		}
+	}
+	return uniqueSorted(tools)
+}
+
+// unavailableTools returns the tools that can't answer on a chain: tools backed by an endpoint the
+// chain's explorer lacks, unless they have a usable fallback, and RPC-only tools when no RPC endpoint
+// is configured. In RPC-only mode these are the disabled tools, plus every backend tool on chains without RPC.
+func unavailableTools(client etherscan.Explorer, chainID string) []string {
+	rpcAvailable := rpc.IsRPCFallbackChain(chainID)
+
+	if rpcOnlyMode {
+		tools := DisabledTools()
+		if !rpcAvailable {
+			for tool := range rpcCapableTools {
+				if !offlineTools[tool] {
+					tools = append(tools, tool)
+				}
+			}
+		}
+		return uniqueSorted(tools)
+	}
+
+	var tools []string
+	for _, endpoint := range etherscan.Endpoints() {
+		if client.Availability(chainID, endpoint.Module, endpoint.Action) != etherscan.Unsupported ||
+			endpoint.Fallback == "estimate" || (endpoint.Fallback == "rpc" && rpcAvailable) {
+			continue
+		}
+		tools = append(tools, endpoint.Tools...)
+	}
+	if !rpcAvailable {
+		tools = append(tools, rpcOnlyTools...)
+		for tool, action := range proxyModuleTools {
+			if client.Availability(chainID, "proxy", action) == etherscan.Unsupported {
+				tools = append(tools, tool)
+			}
+		}
+	}
+	return uniqueSorted(tools)
+}
+
+func uniqueSorted(values []string) []string {
+	seen := make(map[string]bool, len(values))
+	var unique []string
+	for _, value := range values {
+		if !seen[value] {
+			seen[value] = true
+			unique = append(unique, value)
+		}
+	}
+	sort.Strings(unique)
+	return unique
+}
+
+func handleGetChainCapabilities(ctx context.Context, request mcp.CallToolRequest, client etherscan.Explorer) (*mcp.CallToolResult, error) {
+	chainID, ok := request.Params.Arguments["chainID"].(string)
+	if !ok {
+		return nil, fmt.Errorf("chainID must be a string")
+	}
+
+	var endpoints []map[string]interface{}
+	for _, endpoint := range etherscan.Endpoints() {
+		entry := map[string]interface{}{
+			"endpoint":     endpoint.Module + "/" + endpoint.Action,
+			"name":         endpoint.Name,
+			"tier":         endpoint.Tier,
+			"availability": client.Availability(chainID, endpoint.Module, endpoint.Action),
+		}
+		if len(endpoint.Tools) > 0 {
+			entry["tools"] = endpoint.Tools
+		}
+		if endpoint.Fallback != "" {
+			entry["fallback"] = endpoint.Fallback
+		}
+		endpoints = append(endpoints, entry)
+	}
+
+	_, known := chains.Get(chainID)
+	response := map[string]interface{}{
+		"chainID":          chainID,
+		"chain":            chains.Label(chainID),
+		"inRegistry":       known,
+		"explorer":         client.ExplorerName(chainID),
+		"requiresPaidPlan": client.RequiresPaidPlan(chainID),
+		"rpcAvailable":     rpc.IsRPCFallbackChain(chainID),
+		"backendStrategy":  backendStrategy(chainID),
+		"endpoints":        endpoints,
+		"unavailableTools": unavailableTools(client, chainID),
+	}
+	if rpcOnlyMode {
+		response["note"] = "Running in RPC-only mode without an Etherscan API key; explorer endpoints are not used"
+	} else if client.Availability(chainID, "account", "balance") == etherscan.Unknown {
+		response["note"] = "This chain is not covered by the capability matrix; endpoints marked unknown are attempted and may fail upstream"
+	}
+
+	responseJSON, err := json.Marshal(response)
+	if err != nil {
+		return nil, fmt.Errorf("error serializing chain capabilities: %w", err)
+	}
+
+	return mcp.NewToolResultText(string(responseJSON)), nil
+}
@@ -0,0 +1,86 @@
+package mcp
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/huahuayu/etherscan-mcp-server/internal/chains"
+	"github.com/huahuayu/etherscan-mcp-server/internal/etherscan"
+	"github.com/huahuayu/etherscan-mcp-server/internal/rpc"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+)
+
+// chainNameHint is appended to every chainID parameter description
+const chainNameHint = `; chain names and aliases such as "ethereum", "arbitrum", "base" or "bsc" are also accepted`
+
+// withChainResolution wraps a tool handler so that its chainID argument may be a chain name or alias
+func withChainResolution(handler server.ToolHandlerFunc) server.ToolHandlerFunc {
+	return func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		if value, ok := request.Params.Arguments["chainID"].(string); ok {
+			chainID, err := chains.Resolve(value)
+			if err != nil {
+				return nil, err
+			}
+			request.Params.Arguments["chainID"] = chainID
+		}
+		return handler(ctx, request)
+	}
+}
+
+// withChainNameHint documents on a tool's chainID parameter that chain names are accepted
+func withChainNameHint(tool mcp.Tool) mcp.Tool {
+	property, ok := tool.InputSchema.Properties["chainID"].(map[string]interface{})
+	if !ok {
+		return tool
+	}
+	description, _ := property["description"].(string)
+	property["description"] = description + chainNameHint
+	return tool
+}
+
+func handleListChains(ctx context.Context, request mcp.CallToolRequest, client etherscan.Explorer) (*mcp.CallToolResult, error) {
+	var list []map[string]interface{}
+	for _, chain := range chains.All() {
+		entry := map[string]interface{}{
+			"chainID":        chain.ID,
+			"name":           chain.Name,
+			"aliases":        chain.Aliases,
+			"nativeCurrency": chain.NativeCurrency,
+			"explorerURL":    chain.ExplorerURL,
+			"blockTime":      chain.BlockTime,
+			"layer":          chain.Layer,
+			"rpcAvailable":   rpc.IsRPCFallbackChain(chain.ID),
+		}
+		if chain.ParentChainID != "" {
+			entry["parentChain"] = chains.Label(chain.ParentChainID)
+		}
+		if chain.WrappedNative != "" {
+			entry["wrappedNative"] = chain.WrappedNative
+		}
+		if chain.Multicall3 != "" {
+			entry["multicall3"] = chain.Multicall3
+		}
+
+		if specificTools := chainSpecificTools(client, chain.ID); len(specificTools) > 0 {
+			entry["chainSpecificTools"] = specificTools
+		}
+		if unavailable := unavailableTools(client, chain.ID); len(unavailable) > 0 {
+			entry["unavailableTools"] = unavailable
+		}
+
+		list = append(list, entry)
+	}
+
+	listJSON, err := json.Marshal(map[string]interface{}{
+		"chains": list,
+		"count":  len(list),
+		"note":   "Any chain ID supported by the explorer can be used, including chains not listed here",
+	})
+	if err != nil {
+		return nil, fmt.Errorf("error serializing chain list: %w", err)
+	}
+
+	return mcp.NewToolResultText(string(listJSON)), nil
+}
@@ -0,0 +1,424 @@
+package mcp
+
+import (
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"log"
+	"math/big"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/huahuayu/etherscan-mcp-server/internal/chains"
+	"github.com/huahuayu/etherscan-mcp-server/internal/etherscan"
+	"github.com/huahuayu/etherscan-mcp-server/internal/rpc"
+	"github.com/huahuayu/etherscan-mcp-server/internal/units"
+)
+
+// nativeAsset identifies the chain's native currency in asset changes
+const nativeAsset = "native"
+
+// internalTransaction is an entry of Etherscan's txlistinternal response
+type internalTransaction struct {
+	From            string `json:"from"`
+	To              string `json:"to"`
+	Value           string `json:"value"`
+	ContractAddress string `json:"contractAddress"`
+	Type            string `json:"type"`
+	IsError         string `json:"isError"`
+}
+
+// tokenInfo is the metadata used to format token amounts
+type tokenInfo struct {
+	Symbol   string
+	Decimals *int
+}
+
+// assetChange is the net amount of one asset gained or lost by an address
+type assetChange struct {
+	Asset           string `json:"asset"`
+	Symbol          string `json:"symbol,omitempty"`
+	Change          string `json:"change"`
+	ChangeFormatted string `json:"changeFormatted,omitempty"`
+}
+
+// transactionExplainer gathers the pieces of a transaction explanation
+type transactionExplainer struct {
+	client    etherscan.Explorer
+	rpcClient *rpc.Client
+	chainID   string
+	native    chains.Currency
+	resolver  *methodResolver
+	tokens    map[string]*tokenInfo
+	changes   map[string]map[string]*big.Int
+}
+
+// explainTransaction combines a transaction, its receipt, internal transactions, decoded input,
+// decoded logs and token transfers into a single summary
+func explainTransaction(client etherscan.Explorer, rpcClient *rpc.Client, chainID, txHash string) (map[string]interface{}, error) {
+	tx, err := getTransaction(client, rpcClient, chainID, txHash)
+	if err != nil {
+		return nil, err
+	}
+	receipt, err := getReceipt(client, rpcClient, chainID, txHash)
+	if err != nil {
+		return nil, err
+	}
+
+	e := &transactionExplainer{
+		client:    client,
+		rpcClient: rpcClient,
+		chainID:   chainID,
+		native:    chains.Native(chainID),
+		resolver:  newTraceResolver(client, chainID),
+		tokens:    make(map[string]*tokenInfo),
+		changes:   make(map[string]map[string]*big.Int),
+	}
+
+	// Pre-Byzantium receipts have no status, so whether those transactions succeeded is unknown
+	failed := receipt.Status == "0x0"
+	var success interface{}
+	if receipt.Status != "" {
+		success = !failed
+	}
+	input := decodeHexData(tx.Input)
+	value, _ := units.ParseBigInt(tx.Value)
+	if value == nil {
+		value = new(big.Int)
+	}
+
+	explanation := map[string]interface{}{
+		"chainID":     chainID,
+		"txHash":      txHash,
+		"blockNumber": decimalString(tx.BlockNumber),
+		"from":        tx.From,
+		"success":     success,
+		"value": map[string]string{
+			"wei":       value.String(),
+			"formatted": units.FormatUnits(value, e.native.Decimals),
+			"symbol":    e.native.Symbol,
+		},
+	}
+	if success == nil && receipt.Root != "" {
+		explanation["statusNote"] = "Pre-Byzantium receipt with a state root and no status; asset changes assume the transaction succeeded"
+	}
+	if explorerURL := chains.TransactionURL(chainID, txHash); explorerURL != "" {
+		explanation["explorerURL"] = explorerURL
+	}
+
+	target := tx.To
+	if tx.To == "" {
+		target = receipt.ContractAddress
+		explanation["contractCreated"] = receipt.ContractAddress
+	} else {
+		explanation["to"] = tx.To
+	}
+
+	// The input of a contract creation is init code, not a call
+	var decodedInput map[string]interface{}
+	if tx.To != "" {
+		decodedInput = decodeCalldata(client, chainID, tx.To, input)
+	}
+	if decodedInput != nil {
+		explanation["decodedInput"] = decodedInput
+	}
+	explanation["action"] = e.describeAction(tx, target, decodedInput, value)
+
+	if fee := e.fee(receipt); fee != nil {
+		explanation["fee"] = fee
+	}
+
+	if failed {
+		explanation["revert"] = e.revertReason(tx)
+	} else {
+		if value.Sign() > 0 && target != "" {
+			e.addChange(tx.From, nativeAsset, new(big.Int).Neg(value))
+			e.addChange(target, nativeAsset, value)
+		}
+		if internalTxs := e.internalTransactions(txHash); len(internalTxs) > 0 {
+			explanation["internalTransactions"] = internalTxs
+		}
+	}
+
+	if transfers := e.tokenTransfers(receipt.Logs); len(transfers) > 0 {
+		explanation["tokenTransfers"] = transfers
+	}
+	if events := e.events(receipt.Logs); len(events) > 0 {
+		explanation["events"] = events
+	}
+	if changes := e.assetChanges(); len(changes) > 0 {
+		explanation["assetChanges"] = changes
+	}
+
+	return explanation, nil
+}
+
+// describeAction summarizes who called what in one sentence
+func (e *transactionExplainer) describeAction(tx *transactionInfo, target string, decodedInput map[string]interface{}, value *big.Int) string {
+	if tx.To == "" {
+		return fmt.Sprintf("%s deployed contract %s", tx.From, target)
+	}
+	if decodedInput == nil {
+		return fmt.Sprintf("%s sent %s %s to %s", tx.From, units.FormatUnits(value, e.native.Decimals), e.native.Symbol, tx.To)
+	}
+
+	call := fmt.Sprintf("unknown method %s", decodedInput["selector"])
+	if signature, ok := decodedInput["signature"].(string); ok {
+		call = signature
+	}
+	action := fmt.Sprintf("%s called %s on %s", tx.From, call, tx.To)
+	if value.Sign() > 0 {
+		action += fmt.Sprintf(" with %s %s", units.FormatUnits(value, e.native.Decimals), e.native.Symbol)
+	}
+	return action
+}
+
+// fee computes the fee paid in native tokens, including the L1 data fee of OP-stack chains, and, when
+// the price is available, its value in USD at the current price (not the price when it was paid)
+func (e *transactionExplainer) fee(receipt *transactionReceipt) map[string]interface{} {
+	gasUsed, okGas := units.ParseBigInt(receipt.GasUsed)
+	gasPrice, okPrice := units.ParseBigInt(receipt.EffectiveGasPrice)
+	if !okGas || !okPrice {
+		return nil
+	}
+
+	feeWei := new(big.Int).Mul(gasUsed, gasPrice)
+	fee := map[string]interface{}{
+		"gasUsed":           gasUsed.String(),
+		"effectiveGasPrice": units.FormatUnits(gasPrice, gweiDecimals) + " gwei",
+		"nativeSymbol":      e.native.Symbol,
+	}
+	if l1Fee, ok := units.ParseBigInt(receipt.L1Fee); ok {
+		fee["executionFeeWei"] = feeWei.String()
+		fee["l1FeeWei"] = l1Fee.String()
+		feeWei = new(big.Int).Add(feeWei, l1Fee)
+	}
+	fee["wei"] = feeWei.String()
+	fee["native"] = units.FormatUnits(feeWei, e.native.Decimals)
+
+	if price, err := nativePriceUSD(e.client, e.chainID); err == nil {
+		native, _ := strconv.ParseFloat(units.FormatUnits(feeWei, e.native.Decimals), 64)
+		fee["usdAtCurrentPrice"] = strconv.FormatFloat(native*price, 'f', 2, 64)
+		fee["nativePriceUsd"] = price
+	} else {
+		log.Printf("Native token price unavailable for chain %s: %v", e.chainID, err)
+	}
+	return fee
+}
+
+// revertReason decodes why a failed transaction reverted by replaying it at the previous block when an
+// RPC endpoint is available, and otherwise reports Etherscan's error description
+func (e *transactionExplainer) revertReason(tx *transactionInfo) interface{} {
+	if rpc.IsRPCFallbackChain(e.chainID) {
+		blockNumber, ok := units.ParseBigInt(tx.BlockNumber)
+		if ok && blockNumber.Sign() > 0 {
+			callObject := map[string]string{"from": tx.From, "data": tx.Input, "value": tx.Value, "gas": tx.Gas}
+			if tx.To != "" {
+				callObject["to"] = tx.To
+			}
+			parent := new(big.Int).Sub(blockNumber, big.NewInt(1)).String()
+			_, err := e.rpcClient.Call(e.chainID, callObject, parent, nil)
+			if err != nil {
+				if revert, err := describeCallFailure(e.client, e.chainID, tx.To, err); err == nil {
+					return revert
+				}
+			}
+		}
+	}
+
+	result, err := e.client.GetTransactionStatus(e.chainID, tx.Hash)
+	if err == nil {
+		var status struct {
+			ErrDescription string `json:"errDescription"`
+		}
+		if json.Unmarshal(result, &status) == nil && status.ErrDescription != "" {
+			return map[string]string{"reason": status.ErrDescription}
+		}
+	}
+	return map[string]string{"reason": "unknown"}
+}
+
+// internalTransactions lists the value transfers made by contracts during the transaction
+func (e *transactionExplainer) internalTransactions(txHash string) []map[string]interface{} {
+	result, err := e.client.GetInternalTransactionsByHash(e.chainID, txHash)
+	if err != nil {
+		if !etherscan.IsNoRecordsError(err) {
+			log.Printf("Internal transactions unavailable for %s: %v", txHash, err)
+		}
+		return nil
+	}
+
+	var internalTxs []internalTransaction
+	if err := json.Unmarshal(result, &internalTxs); err != nil {
+		log.Printf("Failed to parse internal transactions for %s: %v", txHash, err)
+		return nil
+	}
+
+	var described []map[string]interface{}
+	for _, internalTx := range internalTxs {
+		value, ok := units.ParseBigInt(internalTx.Value)
+		if !ok || internalTx.IsError == "1" {
+			continue
+		}
+		to := internalTx.To
+		if to == "" {
+			to = internalTx.ContractAddress
+		}
+		if value.Sign() > 0 {
+			e.addChange(internalTx.From, nativeAsset, new(big.Int).Neg(value))
+			e.addChange(to, nativeAsset, value)
+		}
+		described = append(described, map[string]interface{}{
+			"type":           internalTx.Type,
+			"from":           internalTx.From,
+			"to":             to,
+			"value":          value.String(),
+			"valueFormatted": units.FormatUnits(value, e.native.Decimals),
+		})
+	}
+	return described
+}
+
+// tokenTransfers describes ERC20 and ERC721 transfers and records them as asset changes
+func (e *transactionExplainer) tokenTransfers(logs []logEntry) []map[string]interface{} {
+	var described []map[string]interface{}
+	for _, transfer := range tokenTransfers(logs) {
+		info := e.token(transfer.Token)
+		entry := map[string]interface{}{
+			"token": transfer.Token,
+			"from":  transfer.From,
+			"to":    transfer.To,
+		}
+		if info.Symbol != "" {
+			entry["symbol"] = info.Symbol
+		}
+
+		if transfer.TokenID != nil {
+			entry["standard"] = "ERC721"
+			entry["tokenId"] = transfer.TokenID.String()
+			e.addChange(transfer.From, transfer.Token, big.NewInt(-1))
+			e.addChange(transfer.To, transfer.Token, big.NewInt(1))
+		} else {
+			entry["standard"] = "ERC20"
+			entry["amount"] = transfer.Amount.String()
+			if info.Decimals != nil {
+				entry["amountFormatted"] = units.FormatUnits(transfer.Amount, *info.Decimals)
+			}
+			e.addChange(transfer.From, transfer.Token, new(big.Int).Neg(transfer.Amount))
+			e.addChange(transfer.To, transfer.Token, transfer.Amount)
+		}
+		described = append(described, entry)
+	}
+	return described
+}
+
+// events decodes the logs of the transaction
+func (e *transactionExplainer) events(logs []logEntry) []map[string]interface{} {
+	var described []map[string]interface{}
+	for _, entry := range logs {
+		event := map[string]interface{}{
+			"address":  entry.Address,
+			"logIndex": decimalString(entry.LogIndex),
+		}
+
+		decodedEvent, source := e.resolver.resolveEvent(entry.Address, entry.Topics)
+		if decodedEvent != nil {
+			event["event"] = decodedEvent.Name
+			event["signature"] = decodedEvent.Signature
+			event["abiSource"] = source
+			if data, err := hex.DecodeString(trimHexPrefix(entry.Data)); err == nil {
+				if arguments, err := decodedEvent.DecodeLog(entry.Topics, data); err == nil {
+					event["arguments"] = arguments
+				}
+			}
+		} else {
+			event["topics"] = entry.Topics
+			event["data"] = entry.Data
+		}
+		described = append(described, event)
+	}
+	return described
+}
+
+// token gets the cached symbol and decimals of a token
+func (e *transactionExplainer) token(address string) *tokenInfo {
+	if info, ok := e.tokens[address]; ok {
+		return info
+	}
+
+	info := &tokenInfo{}
+	if symbol, err := tokenSymbol(e.client, e.rpcClient, e.chainID, address); err == nil {
+		info.Symbol = symbol
+	}
+	if decimals, err := tokenDecimals(e.client, e.rpcClient, e.chainID, address); err == nil {
+		info.Decimals = &decimals
+	}
+	e.tokens[address] = info
+	return info
+}
+
+func (e *transactionExplainer) addChange(address, asset string, amount *big.Int) {
+	address = strings.ToLower(address)
+	if e.changes[address] == nil {
+		e.changes[address] = make(map[string]*big.Int)
+	}
+	if e.changes[address][asset] == nil {
+		e.changes[address][asset] = new(big.Int)
+	}
+	e.changes[address][asset].Add(e.changes[address][asset], amount)
+}
+
+// assetChanges returns the net assets moved in (positive) and out (negative) per address, excluding fees
+func (e *transactionExplainer) assetChanges() map[string][]assetChange {
+	result := make(map[string][]assetChange)
+	for address, assets := range e.changes {
+		var changes []assetChange
+		for asset, amount := range assets {
+			if amount.Sign() == 0 {
+				continue
+			}
+			change := assetChange{Asset: asset, Change: amount.String()}
+			if asset == nativeAsset {
+				change.Symbol = e.native.Symbol
+				change.ChangeFormatted = units.FormatUnits(amount, e.native.Decimals)
+			} else if info := e.tokens[asset]; info != nil {
+				change.Symbol = info.Symbol
+				if info.Decimals != nil {
+					change.ChangeFormatted = units.FormatUnits(amount, *info.Decimals)
+				}
+			}
+			changes = append(changes, change)
+		}
+		if len(changes) == 0 {
+			continue
+		}
+		sort.Slice(changes, func(i, j int) bool { return changes[i].Asset < changes[j].Asset })
+		result[address] = changes
+	}
+	return result
+}
+
+// nativePriceUSD gets the current USD price of the chain's native token
+func nativePriceUSD(client etherscan.Explorer, chainID string) (float64, error) {
+	result, err := client.GetEthPrice(chainID)
+	if err != nil {
+		return 0, err
+	}
+
+	var price struct {
+		EthUSD string `json:"ethusd"`
+	}
+	if err := json.Unmarshal(result, &price); err != nil {
+		return 0, fmt.Errorf("failed to parse native token price: %w", err)
+	}
+	return strconv.ParseFloat(price.EthUSD, 64)
+}
+
+// decimalString converts a decimal or hex quantity to decimal, leaving unparsable values unchanged
+func decimalString(value string) string {
+	if quantity, ok := units.ParseBigInt(value); ok {
+		return quantity.String()
+	}
+	return value
+}
@@ -0,0 +1,179 @@
+package mcp
+
+import (
+	"encoding/json"
+	"fmt"
+	"log"
+	"strings"
+	"sync"
+
+	"github.com/huahuayu/etherscan-mcp-server/internal/chains"
+	"github.com/huahuayu/etherscan-mcp-server/internal/etherscan"
+	"github.com/huahuayu/etherscan-mcp-server/internal/rpc"
+)
+
+// Backend strategies decide whether Etherscan or the chain's RPC endpoint answers the tools that
+// both can serve (balances, calls, blocks, transactions, receipts, code, storage and gas)
+const (
+	strategyEtherscanFirst = "etherscan-first" // Etherscan, falling back to RPC when Etherscan can't serve the chain
+	strategyRPCFirst       = "rpc-first"       // RPC, falling back to Etherscan when the RPC call fails
+	strategyRPCOnly        = "rpc-only"        // RPC only
+)
+
+// Backends reported in the source field of responses, besides the explorer serving the chain
+const (
+	sourceRPC      = "rpc"
+	sourceRegistry = "registry" // answered from the chain registry without a backend
+)
+
+// explorerCapabilities tells which explorer serves each chain; RegisterTools sets it to the registered explorer
+var explorerCapabilities etherscan.Capabilities = etherscan.NewClient("")
+
+var (
+	// defaultStrategy applies to chains without their own strategy; empty selects automatically
+	defaultStrategy  string
+	chainStrategies  = make(map[string]string)
+	strategiesConfMu sync.RWMutex
+)
+
+// SetBackendStrategy sets the backend strategy of a chain, or the default of every chain when chainID is empty.
+// In RPC-only mode there is no Etherscan to fall back to, so other strategies are logged and ignored.
+func SetBackendStrategy(chainID, strategy string) error {
+	switch strategy {
+	case strategyEtherscanFirst, strategyRPCFirst, strategyRPCOnly:
+	default:
+		return fmt.Errorf("unknown backend strategy %q (expected %s, %s or %s)", strategy, strategyEtherscanFirst, strategyRPCFirst, strategyRPCOnly)
+	}
+	if rpcOnlyMode && strategy != strategyRPCOnly {
+		scope := "every chain"
+		if chainID != "" {
+			scope = chains.Label(chainID)
+		}
+		log.Printf("Ignoring backend strategy %s for %s: RPC-only mode serves every chain %s", strategy, scope, strategyRPCOnly)
+		return nil
+	}
+
+	strategiesConfMu.Lock()
+	defer strategiesConfMu.Unlock()
+	if chainID == "" {
+		defaultStrategy = strategy
+	} else {
+		chainStrategies[chainID] = strategy
+	}
+	return nil
+}
+
+// ConfigureBackendStrategies applies a comma-separated list of chain=strategy pairs
+// (e.g. "56=rpc-first,base=rpc-only"); chains may be given by ID or name
+func ConfigureBackendStrategies(spec string) error {
+	for _, entry := range strings.Split(spec, ",") {
+		entry = strings.TrimSpace(entry)
+		if entry == "" {
+			continue
+		}
+		chain, strategy, ok := strings.Cut(entry, "=")
+		if !ok {
+			return fmt.Errorf("invalid backend strategy %q, expected chain=strategy", entry)
+		}
+		chainID, err := chains.Resolve(chain)
+		if err != nil {
+			return err
+		}
+		if err := SetBackendStrategy(chainID, strings.TrimSpace(strategy)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// explorerSource returns the source reported for responses from the explorer serving a chain,
+// such as "etherscan", "blockscout" or "routescan"
+func explorerSource(chainID string) string {
+	return strings.ToLower(explorerCapabilities.ExplorerName(chainID))
+}
+
+// backendStrategy returns the strategy of a chain. Unless configured otherwise, chains that their explorer
+// only serves on paid plans go to RPC first, so that free-plan keys don't waste a call per request.
+func backendStrategy(chainID string) string {
+	strategiesConfMu.RLock()
+	defer strategiesConfMu.RUnlock()
+	if strategy, ok := chainStrategies[chainID]; ok {
+		return strategy
+	}
+	if defaultStrategy != "" {
+		return defaultStrategy
+	}
+	if explorerCapabilities.RequiresPaidPlan(chainID) {
+		return strategyRPCFirst
+	}
+	return strategyEtherscanFirst
+}
+
+// fetch answers a request from the backend chosen by the chain's strategy, falling back to the other
+// backend where the strategy allows it. It returns the backend that answered. Chains without an
+// RPC endpoint are always served by Etherscan.
+func fetch[T any](chainID string, fromEtherscan, fromRPC func() (T, error)) (T, string, error) {
+	var zero T
+	strategy := backendStrategy(chainID)
+
+	if !rpc.IsRPCFallbackChain(chainID) {
+		if strategy == strategyRPCOnly {
+			return zero, "", fmt.Errorf("chain %s is set to %s but has no RPC endpoint (configure one via RPC_URLS)", chainID, strategyRPCOnly)
+		}
+		result, err := fromEtherscan()
+		return result, explorerSource(chainID), err
+	}
+
+	switch strategy {
+	case strategyRPCOnly:
+		result, err := fromRPC()
+		return result, sourceRPC, err
+
+	case strategyRPCFirst:
+		result, err := fromRPC()
+		if err == nil {
+			return result, sourceRPC, nil
+		}
+		log.Printf("RPC failed for chain %s (%v), falling back to Etherscan", chainID, err)
+		result, etherscanErr := fromEtherscan()
+		if etherscanErr != nil {
+			return zero, "", fmt.Errorf("RPC failed (%v) and Etherscan fallback failed: %w", err, etherscanErr)
+		}
+		return result, explorerSource(chainID), nil
+	}
+
+	result, err := fromEtherscan()
+	if err == nil || !etherscan.IsUnavailableError(err) {
+		return result, explorerSource(chainID), err
+	}
+	log.Printf("Etherscan unavailable for chain %s (%v), falling back to RPC", chainID, err)
+	result, err = fromRPC()
+	if err != nil {
+		return zero, "", fmt.Errorf("RPC fallback failed: %w", err)
+	}
+	return result, sourceRPC, nil
+}
+
+// withSource adds the answering backend to a JSON result. Objects get a source field; other values
+// (strings, null) are wrapped as {"result": ..., "source": ...} so the shape doesn't depend on the backend.
+func withSource(result json.RawMessage, source string) (string, error) {
+	var object map[string]json.RawMessage
+	if err := json.Unmarshal(result, &object); err != nil || object == nil {
+		object = map[string]json.RawMessage{"result": result}
+		if len(result) == 0 {
+			object["result"] = json.RawMessage("null")
+		}
+	}
+
+	sourceJSON, err := json.Marshal(source)
+	if err != nil {
+		return "", err
+	}
+	object["source"] = sourceJSON
+
+	responseJSON, err := json.Marshal(object)
+	if err != nil {
+		return "", fmt.Errorf("error serializing response: %w", err)
+	}
+	return string(responseJSON), nil
+}
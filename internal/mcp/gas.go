@@ -0,0 +1,148 @@
+package mcp
+
+import (
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"strconv"
+	"strings"
+
+	"github.com/huahuayu/etherscan-mcp-server/internal/etherscan"
+	"github.com/huahuayu/etherscan-mcp-server/internal/rpc"
+	"github.com/huahuayu/etherscan-mcp-server/internal/units"
+)
+
+// gweiDecimals is the number of decimals between wei and gwei
+const gweiDecimals = 9
+
+// currentGasPrice gets the gas price in wei from the chain's backend, returning the backend that answered
+func currentGasPrice(client etherscan.Explorer, rpcClient *rpc.Client, chainID string) (*big.Int, string, error) {
+	hexPrice, source, err := fetch(chainID,
+		func() (string, error) { return client.GetGasPrice(chainID) },
+		func() (string, error) { return rpcClient.GasPrice(chainID) })
+	if err != nil {
+		return nil, "", err
+	}
+
+	gasPrice, ok := units.ParseBigInt(hexPrice)
+	if !ok {
+		return nil, "", fmt.Errorf("invalid gas price: %s", hexPrice)
+	}
+
+	return gasPrice, source, nil
+}
+
+// estimateGas estimates the gas used by a call on the chain's backend.
+// callObject holds to, from, data and value with numeric values as hex quantities.
+func estimateGas(client etherscan.Explorer, rpcClient *rpc.Client, chainID string, callObject map[string]string) (*big.Int, error) {
+	params := make(map[string]string)
+	for _, key := range []string{"to", "from", "data", "value"} {
+		if value := callObject[key]; value != "" {
+			params[key] = value
+		}
+	}
+
+	var hexGas string
+	var err error
+	if callObject["from"] != "" && rpc.IsRPCFallbackChain(chainID) {
+		// Etherscan's eth_estimateGas doesn't document from, so estimate calls that depend on the sender over RPC
+		hexGas, err = rpcClient.EstimateGas(chainID, callObject)
+	} else {
+		hexGas, _, err = fetch(chainID,
+			func() (string, error) { return client.EstimateGas(chainID, params) },
+			func() (string, error) { return rpcClient.EstimateGas(chainID, callObject) })
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	gas, ok := units.ParseBigInt(hexGas)
+	if !ok {
+		return nil, fmt.Errorf("invalid gas estimate: %s", hexGas)
+	}
+
+	return gas, nil
+}
+
+// gasOracleBlocks is how many recent blocks the RPC gas oracle averages priority fees over
+const gasOracleBlocks = 20
+
+// gasOracleFromFeeHistory builds a gas oracle in Etherscan's format from eth_feeHistory: the safe,
+// proposed and fast prices are the next block's base fee plus the 25th, 50th and 75th percentile
+// priority fees averaged over recent blocks
+func gasOracleFromFeeHistory(rpcClient *rpc.Client, chainID string) (json.RawMessage, error) {
+	result, err := rpcClient.FeeHistory(chainID, gasOracleBlocks, "latest", []float64{25, 50, 75})
+	if err != nil {
+		return nil, err
+	}
+
+	var history struct {
+		OldestBlock   string     `json:"oldestBlock"`
+		BaseFeePerGas []string   `json:"baseFeePerGas"`
+		GasUsedRatio  []float64  `json:"gasUsedRatio"`
+		Reward        [][]string `json:"reward"`
+	}
+	if err := json.Unmarshal(result, &history); err != nil {
+		return nil, fmt.Errorf("failed to parse fee history: %w", err)
+	}
+	if len(history.BaseFeePerGas) == 0 {
+		return nil, fmt.Errorf("fee history for chain %s has no base fees", chainID)
+	}
+
+	// The last base fee is the one of the next block
+	baseFee, ok := units.ParseBigInt(history.BaseFeePerGas[len(history.BaseFeePerGas)-1])
+	if !ok {
+		return nil, fmt.Errorf("invalid base fee: %s", history.BaseFeePerGas[len(history.BaseFeePerGas)-1])
+	}
+
+	tips := make([]*big.Int, 3)
+	for i := range tips {
+		sum, count := new(big.Int), int64(0)
+		for _, rewards := range history.Reward {
+			if i < len(rewards) {
+				if tip, ok := units.ParseBigInt(rewards[i]); ok {
+					sum.Add(sum, tip)
+					count++
+				}
+			}
+		}
+		if count > 0 {
+			sum.Div(sum, big.NewInt(count))
+		}
+		tips[i] = sum
+	}
+
+	oracle := map[string]interface{}{
+		"SafeGasPrice":    units.FormatUnits(new(big.Int).Add(baseFee, tips[0]), gweiDecimals),
+		"ProposeGasPrice": units.FormatUnits(new(big.Int).Add(baseFee, tips[1]), gweiDecimals),
+		"FastGasPrice":    units.FormatUnits(new(big.Int).Add(baseFee, tips[2]), gweiDecimals),
+		"suggestBaseFee":  units.FormatUnits(baseFee, gweiDecimals),
+		"source":          "rpc",
+	}
+	if oldest, ok := units.ParseBigInt(history.OldestBlock); ok && len(history.GasUsedRatio) > 0 {
+		lastBlock := new(big.Int).Add(oldest, big.NewInt(int64(len(history.GasUsedRatio)-1)))
+		oracle["LastBlock"] = lastBlock.String()
+	}
+	var ratios []string
+	for _, ratio := range history.GasUsedRatio {
+		ratios = append(ratios, strconv.FormatFloat(ratio, 'f', -1, 64))
+	}
+	oracle["gasUsedRatio"] = strings.Join(ratios, ",")
+
+	return json.Marshal(oracle)
+}
+
+// hexQuantityArg converts an optional decimal or hex tool argument to a hex quantity
+func hexQuantityArg(arguments map[string]interface{}, name string) (string, error) {
+	value, _ := arguments[name].(string)
+	if value == "" {
+		return "", nil
+	}
+
+	quantity, ok := units.ParseBigInt(value)
+	if !ok || quantity.Sign() < 0 {
+		return "", fmt.Errorf("%s must be a non-negative decimal or hex number", name)
+	}
+
+	return units.ToHex(quantity), nil
+}
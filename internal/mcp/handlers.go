@@ -2,16 +2,27 @@ package mcp
 
 import (
 	"context"
+	"encoding/hex"
+	"encoding/json"
 	"fmt"
 	"log"
-
+	"math"
+	"math/big"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/huahuayu/etherscan-mcp-server/internal/chains"
 	"github.com/huahuayu/etherscan-mcp-server/internal/etherscan"
+	"github.com/huahuayu/etherscan-mcp-server/internal/evm"
 	"github.com/huahuayu/etherscan-mcp-server/internal/rpc"
+	"github.com/huahuayu/etherscan-mcp-server/internal/units"
 	"github.com/mark3labs/mcp-go/mcp"
 )
 
 // Handler functions
-func handleGetAccountBalance(ctx context.Context, request mcp.CallToolRequest, client *etherscan.Client, rpcClient *rpc.Client) (*mcp.CallToolResult, error) {
+func handleGetAccountBalance(ctx context.Context, request mcp.CallToolRequest, client etherscan.Explorer, rpcClient *rpc.Client) (*mcp.CallToolResult, error) {
 	chainID, ok := request.Params.Arguments["chainID"].(string)
 	if !ok {
 		return nil, fmt.Errorf("chainID must be a string")
@@ -22,23 +33,17 @@ func handleGetAccountBalance(ctx context.Context, request mcp.CallToolRequest, c
 		return nil, fmt.Errorf("address must be a string")
 	}
 
-	balance, err := client.GetAccountBalance(chainID, address)
+	balance, source, err := fetch(chainID,
+		func() (string, error) { return client.GetAccountBalance(chainID, address) },
+		func() (string, error) { return rpcClient.GetBalance(chainID, address) })
 	if err != nil {
-		if etherscan.IsNotFreeAPIError(err) && rpc.IsRPCFallbackChain(chainID) {
-			log.Printf("Etherscan API not free for chain %s, falling back to RPC", chainID)
-			balance, err = rpcClient.GetBalance(chainID, address)
-			if err != nil {
-				return nil, fmt.Errorf("RPC fallback failed: %w", err)
-			}
-			return mcp.NewToolResultText(fmt.Sprintf(`{"balance": "%s"}`, balance)), nil
-		}
 		return nil, err
 	}
 
-	return mcp.NewToolResultText(fmt.Sprintf(`{"balance": "%s"}`, balance)), nil
+	return mcp.NewToolResultText(fmt.Sprintf(`{"balance": "%s", "source": "%s"}`, balance, source)), nil
 }
 
-func handleGetBlockByNumber(ctx context.Context, request mcp.CallToolRequest, client *etherscan.Client) (*mcp.CallToolResult, error) {
+func handleGetBlockByNumber(ctx context.Context, request mcp.CallToolRequest, client etherscan.Explorer, rpcClient *rpc.Client) (*mcp.CallToolResult, error) {
 	chainID, ok := request.Params.Arguments["chainID"].(string)
 	if !ok {
 		return nil, fmt.Errorf("chainID must be a string")
@@ -49,7 +54,42 @@ func handleGetBlockByNumber(ctx context.Context, request mcp.CallToolRequest, cl
 		return nil, fmt.Errorf("blockNumber must be a string")
 	}
 
-	result, err := client.GetBlockByNumber(chainID, blockNumber)
+	fullTx, _ := request.Params.Arguments["fullTransactions"].(bool)
+
+	result, source, err := fetch(chainID,
+		func() (json.RawMessage, error) { return client.GetBlockByNumber(chainID, blockNumber, fullTx) },
+		func() (json.RawMessage, error) { return rpcClient.GetBlockByNumber(chainID, blockNumber, fullTx) })
+	if err != nil {
+		return nil, err
+	}
+
+	response, err := withSource(result, source)
+	if err != nil {
+		return nil, err
+	}
+
+	return mcp.NewToolResultText(response), nil
+}
+
+func handleGetBlockByHash(ctx context.Context, request mcp.CallToolRequest, rpcClient *rpc.Client) (*mcp.CallToolResult, error) {
+	chainID, ok := request.Params.Arguments["chainID"].(string)
+	if !ok {
+		return nil, fmt.Errorf("chainID must be a string")
+	}
+
+	blockHash, ok := request.Params.Arguments["blockHash"].(string)
+	if !ok {
+		return nil, fmt.Errorf("blockHash must be a string")
+	}
+
+	fullTx, _ := request.Params.Arguments["fullTransactions"].(bool)
+
+	// The Etherscan proxy module has no eth_getBlockByHash, so this is served by RPC only
+	if !rpc.IsRPCFallbackChain(chainID) {
+		return nil, fmt.Errorf("getBlockByHash requires an RPC endpoint for chain %s (configure one via RPC_URLS)", chainID)
+	}
+
+	result, err := rpcClient.GetBlockByHash(chainID, blockHash, fullTx)
 	if err != nil {
 		return nil, err
 	}
@@ -57,7 +97,7 @@ func handleGetBlockByNumber(ctx context.Context, request mcp.CallToolRequest, cl
 	return mcp.NewToolResultText(string(result)), nil
 }
 
-func handleGetBlockRewards(ctx context.Context, request mcp.CallToolRequest, client *etherscan.Client) (*mcp.CallToolResult, error) {
+func handleGetBlockRewards(ctx context.Context, request mcp.CallToolRequest, client etherscan.Explorer) (*mcp.CallToolResult, error) {
 	chainID, ok := request.Params.Arguments["chainID"].(string)
 	if !ok {
 		return nil, fmt.Errorf("chainID must be a string")
@@ -76,7 +116,7 @@ func handleGetBlockRewards(ctx context.Context, request mcp.CallToolRequest, cli
 	return mcp.NewToolResultText(string(result)), nil
 }
 
-func handleGetContractABI(ctx context.Context, request mcp.CallToolRequest, client *etherscan.Client) (*mcp.CallToolResult, error) {
+func handleGetContractABI(ctx context.Context, request mcp.CallToolRequest, client etherscan.Explorer, rpcClient *rpc.Client) (*mcp.CallToolResult, error) {
 	chainID, ok := request.Params.Arguments["chainID"].(string)
 	if !ok {
 		return nil, fmt.Errorf("chainID must be a string")
@@ -87,15 +127,56 @@ func handleGetContractABI(ctx context.Context, request mcp.CallToolRequest, clie
 		return nil, fmt.Errorf("contractAddress must be a string")
 	}
 
+	includeImplementation, _ := request.Params.Arguments["includeImplementation"].(bool)
+	if !includeImplementation {
+		abi, err := client.GetContractABI(chainID, contractAddress)
+		if err != nil {
+			return nil, err
+		}
+		return mcp.NewToolResultText(abi), nil
+	}
+
+	// Resolve the proxy first: unverified proxies and EIP-1167 clones often delegate to a verified implementation
+	info, err := resolveProxy(client, rpcClient, chainID, contractAddress)
+	if err != nil {
+		return nil, fmt.Errorf("failed to resolve proxy: %w", err)
+	}
+
 	abi, err := client.GetContractABI(chainID, contractAddress)
+	if !info.IsProxy || info.Implementation == "" {
+		if err != nil {
+			return nil, err
+		}
+		return mcp.NewToolResultText(abi), nil
+	}
+	if err != nil && !etherscan.IsNotVerifiedError(err) {
+		return nil, err
+	}
+
+	implementationABI, implementationErr := client.GetContractABI(chainID, info.Implementation)
+	if implementationErr != nil && !etherscan.IsNotVerifiedError(implementationErr) {
+		return nil, fmt.Errorf("failed to get implementation ABI for %s: %w", info.Implementation, implementationErr)
+	}
+
+	// Return whichever of the two ABIs is verified, merged when both are
+	switch {
+	case err != nil && implementationErr != nil:
+		return nil, fmt.Errorf("neither proxy %s nor its implementation %s is verified", contractAddress, info.Implementation)
+	case err != nil:
+		return mcp.NewToolResultText(implementationABI), nil
+	case implementationErr != nil:
+		return mcp.NewToolResultText(abi), nil
+	}
+
+	merged, err := mergeABIs(abi, implementationABI)
 	if err != nil {
 		return nil, err
 	}
 
-	return mcp.NewToolResultText(abi), nil
+	return mcp.NewToolResultText(merged), nil
 }
 
-func handleGetContractSourceCode(ctx context.Context, request mcp.CallToolRequest, client *etherscan.Client) (*mcp.CallToolResult, error) {
+func handleGetContractSourceCode(ctx context.Context, request mcp.CallToolRequest, client etherscan.Explorer) (*mcp.CallToolResult, error) {
 	chainID, ok := request.Params.Arguments["chainID"].(string)
 	if !ok {
 		return nil, fmt.Errorf("chainID must be a string")
@@ -106,15 +187,71 @@ func handleGetContractSourceCode(ctx context.Context, request mcp.CallToolReques
 		return nil, fmt.Errorf("contractAddress must be a string")
 	}
 
+	view, _ := request.Params.Arguments["view"].(string)
+	if view == "" {
+		view = "full"
+	}
+
 	result, err := client.GetContractSourceCode(chainID, contractAddress)
 	if err != nil {
 		return nil, err
 	}
 
-	return mcp.NewToolResultText(string(result)), nil
+	if view == "raw" {
+		return mcp.NewToolResultText(string(result)), nil
+	}
+
+	bundle, err := etherscan.ParseSourceBundle(result)
+	if err != nil {
+		return nil, err
+	}
+
+	var response interface{}
+	switch view {
+	case "full":
+		response = bundle
+
+	case "list":
+		files := make([]map[string]interface{}, 0, len(bundle.Files))
+		for _, path := range bundle.Paths() {
+			files = append(files, map[string]interface{}{
+				"path":      path,
+				"sizeBytes": len(bundle.Files[path]),
+				"lines":     strings.Count(bundle.Files[path], "\n") + 1,
+			})
+		}
+		response = struct {
+			etherscan.SourceBundle
+			Files []map[string]interface{} `json:"files"`
+		}{*bundle, files}
+
+	case "file":
+		filePath, _ := request.Params.Arguments["filePath"].(string)
+		if filePath == "" {
+			return nil, fmt.Errorf("filePath is required when view is 'file'")
+		}
+		path, content, err := bundle.FindFile(filePath)
+		if err != nil {
+			return nil, err
+		}
+		response = map[string]string{
+			"path":    path,
+			"content": content,
+		}
+
+	default:
+		return nil, fmt.Errorf("view must be one of 'full', 'list', 'file' or 'raw'")
+	}
+
+	responseJSON, err := json.Marshal(response)
+	if err != nil {
+		return nil, fmt.Errorf("error serializing source code: %w", err)
+	}
+
+	return mcp.NewToolResultText(string(responseJSON)), nil
 }
 
-func handleExecuteContractMethod(ctx context.Context, request mcp.CallToolRequest, client *etherscan.Client, rpcClient *rpc.Client) (*mcp.CallToolResult, error) {
+func handleExecuteContractMethod(ctx context.Context, request mcp.CallToolRequest, client etherscan.Explorer, rpcClient *rpc.Client) (*mcp.CallToolResult, error) {
 	chainID, ok := request.Params.Arguments["chainID"].(string)
 	if !ok {
 		return nil, fmt.Errorf("chainID must be a string")
@@ -138,37 +275,45 @@ func handleExecuteContractMethod(ctx context.Context, request mcp.CallToolReques
 		data = methodABI + methodParams
 	}
 
-	result, err := client.ExecuteContractMethod(chainID, contractAddress, methodABI, methodParams)
+	result, source, err := fetch(chainID,
+		func() (json.RawMessage, error) {
+			return client.ExecuteContractMethod(chainID, contractAddress, methodABI, methodParams)
+		},
+		func() (json.RawMessage, error) { return rpcClient.EthCall(chainID, contractAddress, data) })
 	if err != nil {
-		if etherscan.IsNotFreeAPIError(err) && rpc.IsRPCFallbackChain(chainID) {
-			log.Printf("Etherscan API not free for chain %s, falling back to RPC", chainID)
-			result, err = rpcClient.EthCall(chainID, contractAddress, data)
-			if err != nil {
-				return nil, fmt.Errorf("RPC fallback failed: %w", err)
-			}
-			return mcp.NewToolResultText(string(result)), nil
-		}
 		return nil, err
 	}
 
-	return mcp.NewToolResultText(string(result)), nil
+	response, err := withSource(result, source)
+	if err != nil {
+		return nil, err
+	}
+
+	return mcp.NewToolResultText(response), nil
 }
 
-func handleGetGasOracle(ctx context.Context, request mcp.CallToolRequest, client *etherscan.Client) (*mcp.CallToolResult, error) {
+func handleGetGasOracle(ctx context.Context, request mcp.CallToolRequest, client etherscan.Explorer, rpcClient *rpc.Client) (*mcp.CallToolResult, error) {
 	chainID, ok := request.Params.Arguments["chainID"].(string)
 	if !ok {
 		return nil, fmt.Errorf("chainID must be a string")
 	}
 
-	result, err := client.GetGasOracle(chainID)
+	result, source, err := fetch(chainID,
+		func() (json.RawMessage, error) { return client.GetGasOracle(chainID) },
+		func() (json.RawMessage, error) { return gasOracleFromFeeHistory(rpcClient, chainID) })
 	if err != nil {
 		return nil, err
 	}
 
-	return mcp.NewToolResultText(string(result)), nil
+	response, err := withSource(result, source)
+	if err != nil {
+		return nil, err
+	}
+
+	return mcp.NewToolResultText(response), nil
 }
 
-func handleGetTokenBalance(ctx context.Context, request mcp.CallToolRequest, client *etherscan.Client, rpcClient *rpc.Client) (*mcp.CallToolResult, error) {
+func handleGetTokenBalance(ctx context.Context, request mcp.CallToolRequest, client etherscan.Explorer, rpcClient *rpc.Client) (*mcp.CallToolResult, error) {
 	chainID, ok := request.Params.Arguments["chainID"].(string)
 	if !ok {
 		return nil, fmt.Errorf("chainID must be a string")
@@ -184,23 +329,17 @@ func handleGetTokenBalance(ctx context.Context, request mcp.CallToolRequest, cli
 		return nil, fmt.Errorf("address must be a string")
 	}
 
-	balance, err := client.GetTokenBalance(chainID, contractAddress, address)
+	balance, source, err := fetch(chainID,
+		func() (string, error) { return client.GetTokenBalance(chainID, contractAddress, address) },
+		func() (string, error) { return rpcClient.GetTokenBalance(chainID, contractAddress, address) })
 	if err != nil {
-		if etherscan.IsNotFreeAPIError(err) && rpc.IsRPCFallbackChain(chainID) {
-			log.Printf("Etherscan API not free for chain %s, falling back to RPC", chainID)
-			balance, err = rpcClient.GetTokenBalance(chainID, contractAddress, address)
-			if err != nil {
-				return nil, fmt.Errorf("RPC fallback failed: %w", err)
-			}
-			return mcp.NewToolResultText(fmt.Sprintf(`{"balance": "%s"}`, balance)), nil
-		}
 		return nil, err
 	}
 
-	return mcp.NewToolResultText(fmt.Sprintf(`{"balance": "%s"}`, balance)), nil
+	return mcp.NewToolResultText(fmt.Sprintf(`{"balance": "%s", "source": "%s"}`, balance, source)), nil
 }
 
-func handleGetTokenDetails(ctx context.Context, request mcp.CallToolRequest, client *etherscan.Client, rpcClient *rpc.Client) (*mcp.CallToolResult, error) {
+func handleGetTokenDetails(ctx context.Context, request mcp.CallToolRequest, client etherscan.Explorer, rpcClient *rpc.Client) (*mcp.CallToolResult, error) {
 	chainID, ok := request.Params.Arguments["chainID"].(string)
 	if !ok {
 		return nil, fmt.Errorf("chainID must be a string")
@@ -211,23 +350,44 @@ func handleGetTokenDetails(ctx context.Context, request mcp.CallToolRequest, cli
 		return nil, fmt.Errorf("contractAddress must be a string")
 	}
 
-	result, err := client.GetTokenDetails(chainID, contractAddress)
+	// The native currency placeholder is answered from the chain registry, since it has no contract to call
+	if strings.EqualFold(contractAddress, etherscan.NativeTokenAddress) {
+		native := chains.Native(chainID)
+		return tokenDetailsResult(etherscan.TokenDetails{Name: native.Name, Symbol: native.Symbol, Decimals: native.Decimals}, sourceRegistry)
+	}
+
+	result, source, err := fetch(chainID,
+		func() (json.RawMessage, error) { return client.GetTokenDetails(chainID, contractAddress) },
+		func() (json.RawMessage, error) { return rpcClient.GetTokenDetails(chainID, contractAddress) })
 	if err != nil {
-		if etherscan.IsNotFreeAPIError(err) && rpc.IsRPCFallbackChain(chainID) {
-			log.Printf("Etherscan API not free for chain %s, falling back to RPC", chainID)
-			result, err = rpcClient.GetTokenDetails(chainID, contractAddress)
-			if err != nil {
-				return nil, fmt.Errorf("RPC fallback failed: %w", err)
-			}
-			return mcp.NewToolResultText(string(result)), nil
-		}
 		return nil, err
 	}
 
-	return mcp.NewToolResultText(string(result)), nil
+	var response struct {
+		Result etherscan.TokenDetails `json:"result"`
+	}
+	if err := json.Unmarshal(result, &response); err != nil {
+		return nil, fmt.Errorf("failed to parse token details: %w", err)
+	}
+
+	return tokenDetailsResult(response.Result, source)
+}
+
+// tokenDetailsResult renders token details in the same shape whichever backend answered
+func tokenDetailsResult(details etherscan.TokenDetails, source string) (*mcp.CallToolResult, error) {
+	responseJSON, err := json.Marshal(map[string]interface{}{
+		"status":  "1",
+		"message": "OK",
+		"result":  details,
+		"source":  source,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("error serializing token details: %w", err)
+	}
+	return mcp.NewToolResultText(string(responseJSON)), nil
 }
 
-func handleGetTransactionByHash(ctx context.Context, request mcp.CallToolRequest, client *etherscan.Client, rpcClient *rpc.Client) (*mcp.CallToolResult, error) {
+func handleGetTransactionByHash(ctx context.Context, request mcp.CallToolRequest, client etherscan.Explorer, rpcClient *rpc.Client) (*mcp.CallToolResult, error) {
 	chainID, ok := request.Params.Arguments["chainID"].(string)
 	if !ok {
 		return nil, fmt.Errorf("chainID must be a string")
@@ -238,23 +398,22 @@ func handleGetTransactionByHash(ctx context.Context, request mcp.CallToolRequest
 		return nil, fmt.Errorf("txHash must be a string")
 	}
 
-	result, err := client.GetTransactionByHash(chainID, txHash)
+	result, source, err := fetch(chainID,
+		func() (json.RawMessage, error) { return client.GetTransactionByHash(chainID, txHash) },
+		func() (json.RawMessage, error) { return rpcClient.GetTransactionByHash(chainID, txHash) })
 	if err != nil {
-		if etherscan.IsNotFreeAPIError(err) && rpc.IsRPCFallbackChain(chainID) {
-			log.Printf("Etherscan API not free for chain %s, falling back to RPC", chainID)
-			result, err = rpcClient.GetTransactionByHash(chainID, txHash)
-			if err != nil {
-				return nil, fmt.Errorf("RPC fallback failed: %w", err)
-			}
-			return mcp.NewToolResultText(string(result)), nil
-		}
 		return nil, err
 	}
 
-	return mcp.NewToolResultText(string(result)), nil
+	response, err := withSource(result, source)
+	if err != nil {
+		return nil, err
+	}
+
+	return mcp.NewToolResultText(response), nil
 }
 
-func handleGetTransactionReceipt(ctx context.Context, request mcp.CallToolRequest, client *etherscan.Client, rpcClient *rpc.Client) (*mcp.CallToolResult, error) {
+func handleGetTransactionReceipt(ctx context.Context, request mcp.CallToolRequest, client etherscan.Explorer, rpcClient *rpc.Client) (*mcp.CallToolResult, error) {
 	chainID, ok := request.Params.Arguments["chainID"].(string)
 	if !ok {
 		return nil, fmt.Errorf("chainID must be a string")
@@ -265,23 +424,22 @@ func handleGetTransactionReceipt(ctx context.Context, request mcp.CallToolReques
 		return nil, fmt.Errorf("txHash must be a string")
 	}
 
-	result, err := client.GetTransactionReceipt(chainID, txHash)
+	result, source, err := fetch(chainID,
+		func() (json.RawMessage, error) { return client.GetTransactionReceipt(chainID, txHash) },
+		func() (json.RawMessage, error) { return rpcClient.GetTransactionReceipt(chainID, txHash) })
 	if err != nil {
-		if etherscan.IsNotFreeAPIError(err) && rpc.IsRPCFallbackChain(chainID) {
-			log.Printf("Etherscan API not free for chain %s, falling back to RPC", chainID)
-			result, err = rpcClient.GetTransactionReceipt(chainID, txHash)
-			if err != nil {
-				return nil, fmt.Errorf("RPC fallback failed: %w", err)
-			}
-			return mcp.NewToolResultText(string(result)), nil
-		}
 		return nil, err
 	}
 
-	return mcp.NewToolResultText(string(result)), nil
+	response, err := withSource(result, source)
+	if err != nil {
+		return nil, err
+	}
+
+	return mcp.NewToolResultText(response), nil
 }
 
-func handleGetTransactionStatus(ctx context.Context, request mcp.CallToolRequest, client *etherscan.Client) (*mcp.CallToolResult, error) {
+func handleGetTransactionStatus(ctx context.Context, request mcp.CallToolRequest, client etherscan.Explorer) (*mcp.CallToolResult, error) {
 	chainID, ok := request.Params.Arguments["chainID"].(string)
 	if !ok {
 		return nil, fmt.Errorf("chainID must be a string")
@@ -300,7 +458,7 @@ func handleGetTransactionStatus(ctx context.Context, request mcp.CallToolRequest
 	return mcp.NewToolResultText(string(result)), nil
 }
 
-func handleGetTransactionsByAddress(ctx context.Context, request mcp.CallToolRequest, client *etherscan.Client) (*mcp.CallToolResult, error) {
+func handleGetTransactionsByAddress(ctx context.Context, request mcp.CallToolRequest, client etherscan.Explorer) (*mcp.CallToolResult, error) {
 	chainID, ok := request.Params.Arguments["chainID"].(string)
 	if !ok {
 		return nil, fmt.Errorf("chainID must be a string")
@@ -337,7 +495,7 @@ func handleGetTransactionsByAddress(ctx context.Context, request mcp.CallToolReq
 	return mcp.NewToolResultText(string(result)), nil
 }
 
-func handleGetInternalTransactionsByAddress(ctx context.Context, request mcp.CallToolRequest, client *etherscan.Client) (*mcp.CallToolResult, error) {
+func handleGetInternalTransactionsByAddress(ctx context.Context, request mcp.CallToolRequest, client etherscan.Explorer) (*mcp.CallToolResult, error) {
 	chainID, ok := request.Params.Arguments["chainID"].(string)
 	if !ok {
 		return nil, fmt.Errorf("chainID must be a string")
@@ -374,7 +532,7 @@ func handleGetInternalTransactionsByAddress(ctx context.Context, request mcp.Cal
 	return mcp.NewToolResultText(string(result)), nil
 }
 
-func handleGetTokenTransfersByAddress(ctx context.Context, request mcp.CallToolRequest, client *etherscan.Client) (*mcp.CallToolResult, error) {
+func handleGetTokenTransfersByAddress(ctx context.Context, request mcp.CallToolRequest, client etherscan.Explorer) (*mcp.CallToolResult, error) {
 	chainID, ok := request.Params.Arguments["chainID"].(string)
 	if !ok {
 		return nil, fmt.Errorf("chainID must be a string")
@@ -415,7 +573,7 @@ func handleGetTokenTransfersByAddress(ctx context.Context, request mcp.CallToolR
 	return mcp.NewToolResultText(string(result)), nil
 }
 
-func handleGetERC721Transfers(ctx context.Context, request mcp.CallToolRequest, client *etherscan.Client) (*mcp.CallToolResult, error) {
+func handleGetERC721Transfers(ctx context.Context, request mcp.CallToolRequest, client etherscan.Explorer) (*mcp.CallToolResult, error) {
 	chainID, ok := request.Params.Arguments["chainID"].(string)
 	if !ok {
 		return nil, fmt.Errorf("chainID must be a string")
@@ -456,29 +614,23 @@ func handleGetERC721Transfers(ctx context.Context, request mcp.CallToolRequest,
 	return mcp.NewToolResultText(string(result)), nil
 }
 
-func handleGetLatestBlockNumber(ctx context.Context, request mcp.CallToolRequest, client *etherscan.Client, rpcClient *rpc.Client) (*mcp.CallToolResult, error) {
+func handleGetLatestBlockNumber(ctx context.Context, request mcp.CallToolRequest, client etherscan.Explorer, rpcClient *rpc.Client) (*mcp.CallToolResult, error) {
 	chainID, ok := request.Params.Arguments["chainID"].(string)
 	if !ok {
 		return nil, fmt.Errorf("chainID must be a string")
 	}
 
-	blockNumber, err := client.GetLatestBlockNumber(chainID)
+	blockNumber, source, err := fetch(chainID,
+		func() (string, error) { return client.GetLatestBlockNumber(chainID) },
+		func() (string, error) { return rpcClient.BlockNumber(chainID) })
 	if err != nil {
-		if etherscan.IsNotFreeAPIError(err) && rpc.IsRPCFallbackChain(chainID) {
-			log.Printf("Etherscan API not free for chain %s, falling back to RPC", chainID)
-			blockNumber, err = rpcClient.BlockNumber(chainID)
-			if err != nil {
-				return nil, fmt.Errorf("RPC fallback failed: %w", err)
-			}
-			return mcp.NewToolResultText(fmt.Sprintf(`{"blockNumber": "%s"}`, blockNumber)), nil
-		}
 		return nil, err
 	}
 
-	return mcp.NewToolResultText(fmt.Sprintf(`{"blockNumber": "%s"}`, blockNumber)), nil
+	return mcp.NewToolResultText(fmt.Sprintf(`{"blockNumber": "%s", "source": "%s"}`, blockNumber, source)), nil
 }
 
-func handleGetTransactionByBlockNumberAndIndex(ctx context.Context, request mcp.CallToolRequest, client *etherscan.Client) (*mcp.CallToolResult, error) {
+func handleGetTransactionByBlockNumberAndIndex(ctx context.Context, request mcp.CallToolRequest, client etherscan.Explorer, rpcClient *rpc.Client) (*mcp.CallToolResult, error) {
 	chainID, ok := request.Params.Arguments["chainID"].(string)
 	if !ok {
 		return nil, fmt.Errorf("chainID must be a string")
@@ -494,15 +646,26 @@ func handleGetTransactionByBlockNumberAndIndex(ctx context.Context, request mcp.
 		return nil, fmt.Errorf("index must be a string")
 	}
 
-	result, err := client.GetTransactionByBlockNumberAndIndex(chainID, blockNumber, index)
+	result, source, err := fetch(chainID,
+		func() (json.RawMessage, error) {
+			return client.GetTransactionByBlockNumberAndIndex(chainID, blockNumber, index)
+		},
+		func() (json.RawMessage, error) {
+			return rpcClient.GetTransactionByBlockNumberAndIndex(chainID, blockNumber, index)
+		})
 	if err != nil {
 		return nil, err
 	}
 
-	return mcp.NewToolResultText(string(result)), nil
+	response, err := withSource(result, source)
+	if err != nil {
+		return nil, err
+	}
+
+	return mcp.NewToolResultText(response), nil
 }
 
-func handleGetTransactionCount(ctx context.Context, request mcp.CallToolRequest, client *etherscan.Client, rpcClient *rpc.Client) (*mcp.CallToolResult, error) {
+func handleGetTransactionCount(ctx context.Context, request mcp.CallToolRequest, client etherscan.Explorer, rpcClient *rpc.Client) (*mcp.CallToolResult, error) {
 	chainID, ok := request.Params.Arguments["chainID"].(string)
 	if !ok {
 		return nil, fmt.Errorf("chainID must be a string")
@@ -515,18 +678,1414 @@ func handleGetTransactionCount(ctx context.Context, request mcp.CallToolRequest,
 
 	tag, _ := request.Params.Arguments["tag"].(string)
 
-	result, err := client.GetTransactionCount(chainID, address, tag)
+	result, source, err := fetch(chainID,
+		func() (json.RawMessage, error) { return client.GetTransactionCount(chainID, address, tag) },
+		func() (json.RawMessage, error) { return rpcClient.GetTransactionCount(chainID, address, tag) })
 	if err != nil {
-		if etherscan.IsNotFreeAPIError(err) && rpc.IsRPCFallbackChain(chainID) {
-			log.Printf("Etherscan API not free for chain %s, falling back to RPC", chainID)
-			result, err = rpcClient.GetTransactionCount(chainID, address, tag)
-			if err != nil {
-				return nil, fmt.Errorf("RPC fallback failed: %w", err)
-			}
-			return mcp.NewToolResultText(string(result)), nil
+		return nil, err
+	}
+
+	response, err := withSource(result, source)
+	if err != nil {
+		return nil, err
+	}
+
+	return mcp.NewToolResultText(response), nil
+}
+
+func handleGetMinedBlocks(ctx context.Context, request mcp.CallToolRequest, client etherscan.Explorer) (*mcp.CallToolResult, error) {
+	chainID, ok := request.Params.Arguments["chainID"].(string)
+	if !ok {
+		return nil, fmt.Errorf("chainID must be a string")
+	}
+
+	address, ok := request.Params.Arguments["address"].(string)
+	if !ok {
+		return nil, fmt.Errorf("address must be a string")
+	}
+
+	blockType, _ := request.Params.Arguments["blockType"].(string)
+	if blockType != "" && blockType != "blocks" && blockType != "uncles" {
+		return nil, fmt.Errorf("blockType must be either 'blocks' or 'uncles'")
+	}
+
+	params := make(map[string]string)
+
+	if page, ok := request.Params.Arguments["page"].(string); ok && page != "" {
+		params["page"] = page
+	}
+
+	if offset, ok := request.Params.Arguments["offset"].(string); ok && offset != "" {
+		params["offset"] = offset
+	}
+
+	result, err := client.GetMinedBlocks(chainID, address, blockType, params)
+	if err != nil {
+		return nil, err
+	}
+
+	var blocks []map[string]interface{}
+	if err := json.Unmarshal(result, &blocks); err != nil {
+		return nil, fmt.Errorf("failed to parse mined blocks: %w", err)
+	}
+
+	// Summarize the returned page so callers don't have to add up rewards themselves
+	totalRewards := new(big.Int)
+	for _, block := range blocks {
+		reward, _ := block["blockReward"].(string)
+		if value, ok := new(big.Int).SetString(reward, 10); ok {
+			totalRewards.Add(totalRewards, value)
 		}
+	}
+
+	response := map[string]interface{}{
+		"summary": map[string]interface{}{
+			"blockCount":   len(blocks),
+			"totalRewards": totalRewards.String(),
+		},
+		"blocks": blocks,
+	}
+
+	responseJSON, err := json.Marshal(response)
+	if err != nil {
+		return nil, fmt.Errorf("error serializing mined blocks: %w", err)
+	}
+
+	return mcp.NewToolResultText(string(responseJSON)), nil
+}
+
+func handleGetBeaconWithdrawals(ctx context.Context, request mcp.CallToolRequest, client etherscan.Explorer) (*mcp.CallToolResult, error) {
+	return handleChainSpecificList(request, client.GetBeaconWithdrawals)
+}
+
+func handleGetPlasmaDeposits(ctx context.Context, request mcp.CallToolRequest, client etherscan.Explorer) (*mcp.CallToolResult, error) {
+	return handleChainSpecificList(request, client.GetPlasmaDeposits)
+}
+
+func handleGetDepositTransactions(ctx context.Context, request mcp.CallToolRequest, client etherscan.Explorer) (*mcp.CallToolResult, error) {
+	return handleChainSpecificList(request, client.GetDepositTransactions)
+}
+
+func handleGetWithdrawalTransactions(ctx context.Context, request mcp.CallToolRequest, client etherscan.Explorer) (*mcp.CallToolResult, error) {
+	return handleChainSpecificList(request, client.GetWithdrawalTransactions)
+}
+
+// handleChainSpecificList handles the address-based list endpoints that only exist on some chains
+func handleChainSpecificList(request mcp.CallToolRequest, list func(chainID, address string, params map[string]string) (json.RawMessage, error)) (*mcp.CallToolResult, error) {
+	chainID, ok := request.Params.Arguments["chainID"].(string)
+	if !ok {
+		return nil, fmt.Errorf("chainID must be a string")
+	}
+
+	address, ok := request.Params.Arguments["address"].(string)
+	if !ok {
+		return nil, fmt.Errorf("address must be a string")
+	}
+
+	params := make(map[string]string)
+
+	if startBlock, ok := request.Params.Arguments["startBlock"].(string); ok && startBlock != "" {
+		params["startblock"] = startBlock
+	}
+
+	if endBlock, ok := request.Params.Arguments["endBlock"].(string); ok && endBlock != "" {
+		params["endblock"] = endBlock
+	}
+
+	if page, ok := request.Params.Arguments["page"].(string); ok && page != "" {
+		params["page"] = page
+	}
+
+	if offset, ok := request.Params.Arguments["offset"].(string); ok && offset != "" {
+		params["offset"] = offset
+	}
+
+	if sort, ok := request.Params.Arguments["sort"].(string); ok && sort != "" {
+		params["sort"] = sort
+	}
+
+	result, err := list(chainID, address, params)
+	if err != nil {
 		return nil, err
 	}
 
 	return mcp.NewToolResultText(string(result)), nil
 }
+
+func handleGetBlockCountdown(ctx context.Context, request mcp.CallToolRequest, client etherscan.Explorer, rpcClient *rpc.Client) (*mcp.CallToolResult, error) {
+	chainID, ok := request.Params.Arguments["chainID"].(string)
+	if !ok {
+		return nil, fmt.Errorf("chainID must be a string")
+	}
+
+	blockNumber, ok := request.Params.Arguments["blockNumber"].(string)
+	if !ok {
+		return nil, fmt.Errorf("blockNumber must be a string")
+	}
+
+	target, err := strconv.ParseUint(blockNumber, 10, 64)
+	if err != nil {
+		return nil, fmt.Errorf("blockNumber must be a decimal block number")
+	}
+
+	result, err := client.GetBlockCountdown(chainID, blockNumber)
+	if err == nil {
+		return mcp.NewToolResultText(string(result)), nil
+	}
+	log.Printf("Block countdown unavailable for chain %s (%v), estimating from recent blocks", chainID, err)
+
+	stats, err := measureBlockTime(client, rpcClient, chainID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to estimate block countdown: %w", err)
+	}
+
+	if target <= stats.Latest.Number {
+		return nil, fmt.Errorf("block %d has already been mined (current block: %d)", target, stats.Latest.Number)
+	}
+
+	remaining := target - stats.Latest.Number
+	seconds := float64(remaining) * stats.AverageBlockTime
+	estimatedTime := time.Unix(stats.Latest.Timestamp, 0).Add(time.Duration(seconds * float64(time.Second)))
+
+	response := map[string]interface{}{
+		"CurrentBlock":      strconv.FormatUint(stats.Latest.Number, 10),
+		"CountdownBlock":    blockNumber,
+		"RemainingBlock":    strconv.FormatUint(remaining, 10),
+		"EstimateTimeInSec": strconv.FormatFloat(seconds, 'f', 1, 64),
+		"estimatedTime":     estimatedTime.UTC().Format(time.RFC3339),
+		"averageBlockTime":  stats.AverageBlockTime,
+		"source":            "estimate",
+	}
+
+	responseJSON, err := json.Marshal(response)
+	if err != nil {
+		return nil, fmt.Errorf("error serializing block countdown: %w", err)
+	}
+
+	return mcp.NewToolResultText(string(responseJSON)), nil
+}
+
+func handleEstimateBlockByTimestamp(ctx context.Context, request mcp.CallToolRequest, client etherscan.Explorer, rpcClient *rpc.Client) (*mcp.CallToolResult, error) {
+	chainID, ok := request.Params.Arguments["chainID"].(string)
+	if !ok {
+		return nil, fmt.Errorf("chainID must be a string")
+	}
+
+	timestampArg, ok := request.Params.Arguments["timestamp"].(string)
+	if !ok {
+		return nil, fmt.Errorf("timestamp must be a string")
+	}
+
+	targetTime, err := parseTimestamp(timestampArg)
+	if err != nil {
+		return nil, err
+	}
+
+	// Past timestamps can be answered exactly by Etherscan
+	if !targetTime.After(time.Now()) {
+		blockNumber, err := client.GetBlockNumberByTime(chainID, strconv.FormatInt(targetTime.Unix(), 10), "before")
+		if err == nil {
+			return mcp.NewToolResultText(fmt.Sprintf(`{"blockNumber": "%s", "estimated": false, "source": "%s"}`, blockNumber, explorerSource(chainID))), nil
+		}
+		log.Printf("Block by timestamp unavailable for chain %s (%v), estimating from recent blocks", chainID, err)
+	}
+
+	stats, err := measureBlockTime(client, rpcClient, chainID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to estimate block number: %w", err)
+	}
+
+	blocksDelta := float64(targetTime.Unix()-stats.Latest.Timestamp) / stats.AverageBlockTime
+	estimated := int64(stats.Latest.Number) + int64(blocksDelta)
+	if estimated < 0 {
+		estimated = 0
+	}
+
+	response := map[string]interface{}{
+		"blockNumber":        strconv.FormatInt(estimated, 10),
+		"estimated":          true,
+		"targetTime":         targetTime.UTC().Format(time.RFC3339),
+		"referenceBlock":     strconv.FormatUint(stats.Latest.Number, 10),
+		"referenceTimestamp": time.Unix(stats.Latest.Timestamp, 0).UTC().Format(time.RFC3339),
+		"averageBlockTime":   stats.AverageBlockTime,
+		"source":             "estimate",
+	}
+
+	responseJSON, err := json.Marshal(response)
+	if err != nil {
+		return nil, fmt.Errorf("error serializing block estimate: %w", err)
+	}
+
+	return mcp.NewToolResultText(string(responseJSON)), nil
+}
+
+// parseTimestamp accepts either unix seconds or an RFC 3339 date-time
+func parseTimestamp(value string) (time.Time, error) {
+	if seconds, err := strconv.ParseInt(value, 10, 64); err == nil {
+		return time.Unix(seconds, 0), nil
+	}
+	if t, err := time.Parse(time.RFC3339, value); err == nil {
+		return t, nil
+	}
+	return time.Time{}, fmt.Errorf("timestamp must be unix seconds or an RFC 3339 date-time (e.g. 2025-06-01T00:00:00Z)")
+}
+
+func handleGetEthSupply(ctx context.Context, request mcp.CallToolRequest, client etherscan.Explorer) (*mcp.CallToolResult, error) {
+	chainID, ok := request.Params.Arguments["chainID"].(string)
+	if !ok {
+		return nil, fmt.Errorf("chainID must be a string")
+	}
+
+	detailed, _ := request.Params.Arguments["detailed"].(bool)
+
+	var result json.RawMessage
+	var err error
+	if detailed {
+		result, err = client.GetEthSupply2(chainID)
+	} else {
+		result, err = client.GetEthSupply(chainID)
+	}
+	if err != nil {
+		return nil, describeProError("getEthSupply", err)
+	}
+
+	if !detailed {
+		var supply string
+		if err := json.Unmarshal(result, &supply); err != nil {
+			return nil, fmt.Errorf("failed to parse supply: %w", err)
+		}
+		return mcp.NewToolResultText(fmt.Sprintf(`{"supply": "%s"}`, supply)), nil
+	}
+
+	return mcp.NewToolResultText(string(result)), nil
+}
+
+func handleGetEthPrice(ctx context.Context, request mcp.CallToolRequest, client etherscan.Explorer) (*mcp.CallToolResult, error) {
+	chainID, ok := request.Params.Arguments["chainID"].(string)
+	if !ok {
+		return nil, fmt.Errorf("chainID must be a string")
+	}
+
+	result, err := client.GetEthPrice(chainID)
+	if err != nil {
+		return nil, describeProError("getEthPrice", err)
+	}
+
+	return mcp.NewToolResultText(string(result)), nil
+}
+
+func handleGetChainSize(ctx context.Context, request mcp.CallToolRequest, client etherscan.Explorer) (*mcp.CallToolResult, error) {
+	chainID, ok := request.Params.Arguments["chainID"].(string)
+	if !ok {
+		return nil, fmt.Errorf("chainID must be a string")
+	}
+
+	params, err := dateRangeParams(request)
+	if err != nil {
+		return nil, err
+	}
+
+	if clientType, ok := request.Params.Arguments["clientType"].(string); ok && clientType != "" {
+		params["clienttype"] = clientType
+	}
+
+	if syncMode, ok := request.Params.Arguments["syncMode"].(string); ok && syncMode != "" {
+		params["syncmode"] = syncMode
+	}
+
+	result, err := client.GetChainSize(chainID, params)
+	if err != nil {
+		return nil, describeProError("getChainSize", err)
+	}
+
+	return mcp.NewToolResultText(string(result)), nil
+}
+
+func handleGetNodeCount(ctx context.Context, request mcp.CallToolRequest, client etherscan.Explorer) (*mcp.CallToolResult, error) {
+	chainID, ok := request.Params.Arguments["chainID"].(string)
+	if !ok {
+		return nil, fmt.Errorf("chainID must be a string")
+	}
+
+	result, err := client.GetNodeCount(chainID)
+	if err != nil {
+		return nil, describeProError("getNodeCount", err)
+	}
+
+	return mcp.NewToolResultText(string(result)), nil
+}
+
+func handleGetDailyStats(ctx context.Context, request mcp.CallToolRequest, client etherscan.Explorer) (*mcp.CallToolResult, error) {
+	chainID, ok := request.Params.Arguments["chainID"].(string)
+	if !ok {
+		return nil, fmt.Errorf("chainID must be a string")
+	}
+
+	metric, ok := request.Params.Arguments["metric"].(string)
+	if !ok {
+		return nil, fmt.Errorf("metric must be a string")
+	}
+
+	params, err := dateRangeParams(request)
+	if err != nil {
+		return nil, err
+	}
+
+	result, err := client.GetDailyStats(chainID, metric, params)
+	if err != nil {
+		return nil, describeProError("getDailyStats", err)
+	}
+
+	return mcp.NewToolResultText(string(result)), nil
+}
+
+// dateRangeParams reads the startDate and endDate arguments of the stats tools, which the endpoints
+// require, and the optional sort argument
+func dateRangeParams(request mcp.CallToolRequest) (map[string]string, error) {
+	params := make(map[string]string)
+
+	for _, arg := range []string{"startDate", "endDate"} {
+		value, ok := request.Params.Arguments[arg].(string)
+		if !ok || value == "" {
+			return nil, fmt.Errorf("startDate and endDate are required")
+		}
+		if _, err := time.Parse("2006-01-02", value); err != nil {
+			return nil, fmt.Errorf("%s must be in yyyy-MM-dd format", arg)
+		}
+		params[strings.ToLower(arg)] = value
+	}
+
+	if sort, ok := request.Params.Arguments["sort"].(string); ok && sort != "" {
+		params["sort"] = sort
+	}
+
+	return params, nil
+}
+
+// describeProError explains which tool needs an upgraded plan when Etherscan rejects a Pro endpoint
+func describeProError(tool string, err error) error {
+	if etherscan.IsProEndpointError(err) {
+		return fmt.Errorf("%s uses an Etherscan API Pro endpoint, which the configured API key's plan does not include: %w", tool, err)
+	}
+	return err
+}
+
+func handleGetTokenHolderCount(ctx context.Context, request mcp.CallToolRequest, client etherscan.Explorer) (*mcp.CallToolResult, error) {
+	chainID, ok := request.Params.Arguments["chainID"].(string)
+	if !ok {
+		return nil, fmt.Errorf("chainID must be a string")
+	}
+
+	contractAddress, ok := request.Params.Arguments["contractAddress"].(string)
+	if !ok {
+		return nil, fmt.Errorf("contractAddress must be a string")
+	}
+
+	count, err := client.GetTokenHolderCount(chainID, contractAddress)
+	if err != nil {
+		return nil, describeProError("getTokenHolderCount", err)
+	}
+
+	return mcp.NewToolResultText(fmt.Sprintf(`{"holderCount": "%s"}`, count)), nil
+}
+
+func handleGetTopHolders(ctx context.Context, request mcp.CallToolRequest, client etherscan.Explorer, rpcClient *rpc.Client) (*mcp.CallToolResult, error) {
+	chainID, ok := request.Params.Arguments["chainID"].(string)
+	if !ok {
+		return nil, fmt.Errorf("chainID must be a string")
+	}
+
+	contractAddress, ok := request.Params.Arguments["contractAddress"].(string)
+	if !ok {
+		return nil, fmt.Errorf("contractAddress must be a string")
+	}
+
+	limit := 50
+	if l, ok := request.Params.Arguments["limit"].(string); ok && l != "" {
+		parsed, err := strconv.Atoi(l)
+		if err != nil || parsed <= 0 {
+			return nil, fmt.Errorf("limit must be a positive integer")
+		}
+		limit = parsed
+	}
+
+	// tokenholderlist isn't ordered by balance, so rank a larger sample than requested
+	sampleSize := max(limit, topHoldersSampleSize)
+	result, err := client.GetTokenHolderList(chainID, contractAddress, map[string]string{
+		"page":   "1",
+		"offset": strconv.Itoa(sampleSize),
+	})
+	if err != nil {
+		return nil, describeProError("getTopHolders", err)
+	}
+
+	var holderList []struct {
+		Address  string `json:"TokenHolderAddress"`
+		Quantity string `json:"TokenHolderQuantity"`
+		balance  *big.Int
+	}
+	if err := json.Unmarshal(result, &holderList); err != nil {
+		return nil, fmt.Errorf("failed to parse token holders: %w", err)
+	}
+	for i, holder := range holderList {
+		balance, ok := new(big.Int).SetString(holder.Quantity, 10)
+		if !ok {
+			return nil, fmt.Errorf("invalid balance for holder %s: %s", holder.Address, holder.Quantity)
+		}
+		holderList[i].balance = balance
+	}
+	sort.SliceStable(holderList, func(i, j int) bool {
+		return holderList[i].balance.Cmp(holderList[j].balance) > 0
+	})
+	sampled := len(holderList)
+	if len(holderList) > limit {
+		holderList = holderList[:limit]
+	}
+
+	decimals, err := tokenDecimals(client, rpcClient, chainID, contractAddress)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get token decimals: %w", err)
+	}
+
+	totalSupply, err := tokenTotalSupply(client, rpcClient, chainID, contractAddress)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get token total supply: %w", err)
+	}
+
+	holders := make([]map[string]interface{}, 0, len(holderList))
+	balances := make([]*big.Int, 0, len(holderList))
+	top10 := new(big.Int)
+	for i, holder := range holderList {
+		balance := holder.balance
+		balances = append(balances, balance)
+		if i < 10 {
+			top10.Add(top10, balance)
+		}
+
+		holders = append(holders, map[string]interface{}{
+			"rank":             i + 1,
+			"address":          holder.Address,
+			"balance":          balance.String(),
+			"balanceFormatted": units.FormatUnits(balance, decimals),
+			"percentOfSupply":  percentage(balance, totalSupply),
+		})
+	}
+
+	token := map[string]interface{}{
+		"contractAddress":      contractAddress,
+		"decimals":             decimals,
+		"totalSupply":          totalSupply.String(),
+		"totalSupplyFormatted": units.FormatUnits(totalSupply, decimals),
+	}
+	if count, err := client.GetTokenHolderCount(chainID, contractAddress); err == nil {
+		token["holderCount"] = count
+	}
+
+	response := map[string]interface{}{
+		"token":   token,
+		"holders": holders,
+		"concentration": map[string]interface{}{
+			"holdersAnalyzed":  len(holders),
+			"holdersSampled":   sampled,
+			"top10Percent":     percentage(top10, totalSupply),
+			"giniOfTopHolders": giniCoefficient(balances),
+		},
+	}
+	if sampled >= sampleSize {
+		// A full page means there may be more holders than were fetched
+		response["note"] = fmt.Sprintf("Holders are ranked within the first %d returned by the explorer, which are not ordered by balance; larger holders outside this sample are not included in the ranking or the concentration metrics", sampled)
+	}
+
+	responseJSON, err := json.Marshal(response)
+	if err != nil {
+		return nil, fmt.Errorf("error serializing token holders: %w", err)
+	}
+
+	return mcp.NewToolResultText(string(responseJSON)), nil
+}
+
+// topHoldersSampleSize is the minimum number of holders getTopHolders fetches to rank by balance
+const topHoldersSampleSize = 1000
+
+// percentage returns part/total as a percentage rounded to four decimal places
+func percentage(part, total *big.Int) float64 {
+	return math.Round(units.Ratio(part, total)*100*10000) / 10000
+}
+
+func handleGetTokenSupply(ctx context.Context, request mcp.CallToolRequest, client etherscan.Explorer, rpcClient *rpc.Client) (*mcp.CallToolResult, error) {
+	chainID, ok := request.Params.Arguments["chainID"].(string)
+	if !ok {
+		return nil, fmt.Errorf("chainID must be a string")
+	}
+
+	contractAddress, ok := request.Params.Arguments["contractAddress"].(string)
+	if !ok {
+		return nil, fmt.Errorf("contractAddress must be a string")
+	}
+
+	blockNumber, _ := request.Params.Arguments["blockNumber"].(string)
+	if blockNumber == "" {
+		blockNumber = "latest"
+	}
+
+	excluded := burnAddresses
+	if excludeAddresses, ok := request.Params.Arguments["excludeAddresses"].(string); ok && excludeAddresses != "" {
+		excluded = nil
+		if strings.EqualFold(strings.TrimSpace(excludeAddresses), "none") {
+			excludeAddresses = ""
+		}
+		for _, address := range strings.Split(excludeAddresses, ",") {
+			address = strings.TrimSpace(address)
+			if address == "" {
+				continue
+			}
+			if !addressPattern.MatchString(address) {
+				return nil, fmt.Errorf("invalid address in excludeAddresses: %q", address)
+			}
+			excluded = append(excluded, address)
+		}
+	}
+
+	// Prefer the Etherscan stats endpoints, then fall back to calling totalSupply() directly
+	var supplyValue string
+	var err error
+	if blockNumber == "latest" {
+		supplyValue, err = client.GetTokenSupply(chainID, contractAddress)
+	} else {
+		supplyValue, err = client.GetTokenSupplyHistory(chainID, contractAddress, blockNumber)
+	}
+
+	source := explorerSource(chainID)
+	totalSupply, ok := new(big.Int).SetString(supplyValue, 10)
+	if err != nil || !ok {
+		log.Printf("Token supply endpoint unavailable for chain %s (%v), calling totalSupply()", chainID, err)
+		source = "eth_call"
+		totalSupply, err = tokenTotalSupplyAt(client, rpcClient, chainID, contractAddress, blockNumber)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get token supply: %w", err)
+		}
+	}
+
+	decimals, err := tokenDecimals(client, rpcClient, chainID, contractAddress)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get token decimals: %w", err)
+	}
+
+	// Circulating supply excludes balances held by burn (or caller-specified) addresses. Balances that
+	// can't be read, such as historical ones without an RPC endpoint, are reported as unavailable.
+	circulating := new(big.Int).Set(totalSupply)
+	complete := true
+	excludedBalances := make([]map[string]string, 0, len(excluded))
+	for _, address := range excluded {
+		balance, err := tokenBalanceAt(client, rpcClient, chainID, contractAddress, address, blockNumber)
+		if err != nil {
+			log.Printf("Failed to get balance of excluded address %s: %v", address, err)
+			complete = false
+			excludedBalances = append(excludedBalances, map[string]string{
+				"address": address,
+				"error":   err.Error(),
+			})
+			continue
+		}
+		circulating.Sub(circulating, balance)
+		excludedBalances = append(excludedBalances, map[string]string{
+			"address":          address,
+			"balance":          balance.String(),
+			"balanceFormatted": units.FormatUnits(balance, decimals),
+		})
+	}
+
+	response := map[string]interface{}{
+		"contractAddress":      contractAddress,
+		"blockNumber":          blockNumber,
+		"decimals":             decimals,
+		"totalSupply":          totalSupply.String(),
+		"totalSupplyFormatted": units.FormatUnits(totalSupply, decimals),
+		"excludedBalances":     excludedBalances,
+		"source":               source,
+	}
+	if complete {
+		response["circulatingSupply"] = circulating.String()
+		response["circulatingSupplyFormatted"] = units.FormatUnits(circulating, decimals)
+	} else {
+		response["note"] = "Circulating supply is unavailable because some excluded balances could not be read"
+	}
+
+	responseJSON, err := json.Marshal(response)
+	if err != nil {
+		return nil, fmt.Errorf("error serializing token supply: %w", err)
+	}
+
+	return mcp.NewToolResultText(string(responseJSON)), nil
+}
+
+func handleGetAddressLabel(ctx context.Context, request mcp.CallToolRequest, client etherscan.Explorer) (*mcp.CallToolResult, error) {
+	chainID, ok := request.Params.Arguments["chainID"].(string)
+	if !ok {
+		return nil, fmt.Errorf("chainID must be a string")
+	}
+
+	addressArg, ok := request.Params.Arguments["address"].(string)
+	if !ok {
+		return nil, fmt.Errorf("address must be a string")
+	}
+
+	response := make(map[string]*addressLabel)
+	for _, address := range strings.Split(addressArg, ",") {
+		address = strings.TrimSpace(address)
+		if address == "" {
+			continue
+		}
+		label, err := labels.lookup(client, chainID, address)
+		if err != nil {
+			return nil, describeProError("getAddressLabel", err)
+		}
+		response[address] = label
+	}
+
+	responseJSON, err := json.Marshal(response)
+	if err != nil {
+		return nil, fmt.Errorf("error serializing address labels: %w", err)
+	}
+
+	return mcp.NewToolResultText(string(responseJSON)), nil
+}
+
+func handleGetGasEstimate(ctx context.Context, request mcp.CallToolRequest, client etherscan.Explorer) (*mcp.CallToolResult, error) {
+	chainID, ok := request.Params.Arguments["chainID"].(string)
+	if !ok {
+		return nil, fmt.Errorf("chainID must be a string")
+	}
+
+	gasPrice, ok := request.Params.Arguments["gasPrice"].(string)
+	if !ok {
+		return nil, fmt.Errorf("gasPrice must be a string")
+	}
+
+	seconds, err := client.GetGasEstimate(chainID, gasPrice)
+	if err != nil {
+		return nil, err
+	}
+
+	return mcp.NewToolResultText(fmt.Sprintf(`{"gasPrice": "%s", "estimatedConfirmationSeconds": "%s"}`, gasPrice, seconds)), nil
+}
+
+func handleGetGasPrice(ctx context.Context, request mcp.CallToolRequest, client etherscan.Explorer, rpcClient *rpc.Client) (*mcp.CallToolResult, error) {
+	chainID, ok := request.Params.Arguments["chainID"].(string)
+	if !ok {
+		return nil, fmt.Errorf("chainID must be a string")
+	}
+
+	gasPrice, source, err := currentGasPrice(client, rpcClient, chainID)
+	if err != nil {
+		return nil, err
+	}
+
+	response := map[string]interface{}{
+		"gasPrice":     gasPrice.String(),
+		"gasPriceGwei": units.FormatUnits(gasPrice, gweiDecimals),
+		"source":       source,
+	}
+
+	// The priority fee is only exposed over RPC
+	if rpc.IsRPCFallbackChain(chainID) {
+		if hexFee, err := rpcClient.MaxPriorityFeePerGas(chainID); err == nil {
+			if fee, ok := units.ParseBigInt(hexFee); ok {
+				response["maxPriorityFeePerGas"] = fee.String()
+				response["maxPriorityFeePerGasGwei"] = units.FormatUnits(fee, gweiDecimals)
+			}
+		}
+	}
+
+	responseJSON, err := json.Marshal(response)
+	if err != nil {
+		return nil, fmt.Errorf("error serializing gas price: %w", err)
+	}
+
+	return mcp.NewToolResultText(string(responseJSON)), nil
+}
+
+func handleGetFeeHistory(ctx context.Context, request mcp.CallToolRequest, rpcClient *rpc.Client) (*mcp.CallToolResult, error) {
+	chainID, ok := request.Params.Arguments["chainID"].(string)
+	if !ok {
+		return nil, fmt.Errorf("chainID must be a string")
+	}
+
+	if !rpc.IsRPCFallbackChain(chainID) {
+		return nil, fmt.Errorf("getFeeHistory requires an RPC endpoint for chain %s (configure one via RPC_URLS)", chainID)
+	}
+
+	blockCount := uint64(10)
+	if count, ok := request.Params.Arguments["blockCount"].(string); ok && count != "" {
+		parsed, err := strconv.ParseUint(count, 10, 64)
+		if err != nil || parsed == 0 || parsed > 1024 {
+			return nil, fmt.Errorf("blockCount must be a number between 1 and 1024")
+		}
+		blockCount = parsed
+	}
+
+	newestBlock, _ := request.Params.Arguments["newestBlock"].(string)
+	if newestBlock == "" {
+		newestBlock = "latest"
+	}
+
+	percentiles := []float64{25, 50, 75}
+	if percentilesArg, ok := request.Params.Arguments["rewardPercentiles"].(string); ok && percentilesArg != "" {
+		percentiles = nil
+		for _, p := range strings.Split(percentilesArg, ",") {
+			value, err := strconv.ParseFloat(strings.TrimSpace(p), 64)
+			if err != nil || value < 0 || value > 100 {
+				return nil, fmt.Errorf("rewardPercentiles must be comma-separated numbers between 0 and 100")
+			}
+			percentiles = append(percentiles, value)
+		}
+	}
+
+	result, err := rpcClient.FeeHistory(chainID, blockCount, newestBlock, percentiles)
+	if err != nil {
+		return nil, err
+	}
+
+	return mcp.NewToolResultText(string(result)), nil
+}
+
+func handleEstimateGas(ctx context.Context, request mcp.CallToolRequest, client etherscan.Explorer, rpcClient *rpc.Client) (*mcp.CallToolResult, error) {
+	chainID, ok := request.Params.Arguments["chainID"].(string)
+	if !ok {
+		return nil, fmt.Errorf("chainID must be a string")
+	}
+
+	callObject := make(map[string]string)
+	for _, key := range []string{"to", "from", "data"} {
+		if value, ok := request.Params.Arguments[key].(string); ok && value != "" {
+			callObject[key] = value
+		}
+	}
+
+	value, err := hexQuantityArg(request.Params.Arguments, "value")
+	if err != nil {
+		return nil, err
+	}
+	if value != "" {
+		callObject["value"] = value
+	}
+
+	gas, err := estimateGas(client, rpcClient, chainID, callObject)
+	if err != nil {
+		return nil, err
+	}
+
+	gasPrice, source, err := currentGasPrice(client, rpcClient, chainID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get gas price: %w", err)
+	}
+
+	cost := new(big.Int).Mul(gas, gasPrice)
+	response := map[string]interface{}{
+		"gas":              gas.String(),
+		"gasPrice":         gasPrice.String(),
+		"gasPriceGwei":     units.FormatUnits(gasPrice, gweiDecimals),
+		"estimatedCostWei": cost.String(),
+		"estimatedCost":    units.FormatUnits(cost, 18),
+		"source":           source,
+	}
+
+	responseJSON, err := json.Marshal(response)
+	if err != nil {
+		return nil, fmt.Errorf("error serializing gas estimate: %w", err)
+	}
+
+	return mcp.NewToolResultText(string(responseJSON)), nil
+}
+
+func handleGetContractCode(ctx context.Context, request mcp.CallToolRequest, client etherscan.Explorer, rpcClient *rpc.Client) (*mcp.CallToolResult, error) {
+	chainID, ok := request.Params.Arguments["chainID"].(string)
+	if !ok {
+		return nil, fmt.Errorf("chainID must be a string")
+	}
+
+	address, ok := request.Params.Arguments["address"].(string)
+	if !ok {
+		return nil, fmt.Errorf("address must be a string")
+	}
+
+	tag, _ := request.Params.Arguments["tag"].(string)
+
+	code, source, err := getCode(client, rpcClient, chainID, address, tag)
+	if err != nil {
+		return nil, err
+	}
+
+	size := len(trimHexPrefix(code)) / 2
+	response := map[string]interface{}{
+		"address":    address,
+		"code":       code,
+		"sizeBytes":  size,
+		"isContract": size > 0,
+		"source":     source,
+	}
+
+	responseJSON, err := json.Marshal(response)
+	if err != nil {
+		return nil, fmt.Errorf("error serializing contract code: %w", err)
+	}
+
+	return mcp.NewToolResultText(string(responseJSON)), nil
+}
+
+func handleGetStorageAt(ctx context.Context, request mcp.CallToolRequest, client etherscan.Explorer, rpcClient *rpc.Client) (*mcp.CallToolResult, error) {
+	chainID, ok := request.Params.Arguments["chainID"].(string)
+	if !ok {
+		return nil, fmt.Errorf("chainID must be a string")
+	}
+
+	address, ok := request.Params.Arguments["address"].(string)
+	if !ok {
+		return nil, fmt.Errorf("address must be a string")
+	}
+
+	slotArg, ok := request.Params.Arguments["slot"].(string)
+	if !ok {
+		return nil, fmt.Errorf("slot must be a string")
+	}
+
+	slot, err := evm.ParseSlot(slotArg)
+	if err != nil {
+		return nil, err
+	}
+
+	if path, ok := request.Params.Arguments["path"].(string); ok && path != "" {
+		slot, err = resolveSlotPath(slot, path)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	tag, _ := request.Params.Arguments["tag"].(string)
+
+	value, source, err := getStorageAt(client, rpcClient, chainID, address, slot, tag)
+	if err != nil {
+		return nil, err
+	}
+
+	response := describeStorageValue(slot, value)
+	response["source"] = source
+
+	responseJSON, err := json.Marshal(response)
+	if err != nil {
+		return nil, fmt.Errorf("error serializing storage value: %w", err)
+	}
+
+	return mcp.NewToolResultText(string(responseJSON)), nil
+}
+
+func handleComputeStorageSlot(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	baseSlotArg, ok := request.Params.Arguments["baseSlot"].(string)
+	if !ok {
+		return nil, fmt.Errorf("baseSlot must be a string")
+	}
+
+	path, ok := request.Params.Arguments["path"].(string)
+	if !ok {
+		return nil, fmt.Errorf("path must be a string")
+	}
+
+	baseSlot, err := evm.ParseSlot(baseSlotArg)
+	if err != nil {
+		return nil, err
+	}
+
+	slot, err := resolveSlotPath(baseSlot, path)
+	if err != nil {
+		return nil, err
+	}
+
+	return mcp.NewToolResultText(fmt.Sprintf(`{"slot": "%s"}`, evm.FormatSlot(slot))), nil
+}
+
+func handleResolveProxy(ctx context.Context, request mcp.CallToolRequest, client etherscan.Explorer, rpcClient *rpc.Client) (*mcp.CallToolResult, error) {
+	chainID, ok := request.Params.Arguments["chainID"].(string)
+	if !ok {
+		return nil, fmt.Errorf("chainID must be a string")
+	}
+
+	contractAddress, ok := request.Params.Arguments["contractAddress"].(string)
+	if !ok {
+		return nil, fmt.Errorf("contractAddress must be a string")
+	}
+
+	info, err := resolveProxy(client, rpcClient, chainID, contractAddress)
+	if err != nil {
+		return nil, err
+	}
+
+	responseJSON, err := json.Marshal(info)
+	if err != nil {
+		return nil, fmt.Errorf("error serializing proxy info: %w", err)
+	}
+
+	return mcp.NewToolResultText(string(responseJSON)), nil
+}
+
+func handleVerifyContractSource(ctx context.Context, request mcp.CallToolRequest, client etherscan.Explorer) (*mcp.CallToolResult, error) {
+	chainID, ok := request.Params.Arguments["chainID"].(string)
+	if !ok {
+		return nil, fmt.Errorf("chainID must be a string")
+	}
+
+	contractAddress, ok := request.Params.Arguments["contractAddress"].(string)
+	if !ok {
+		return nil, fmt.Errorf("contractAddress must be a string")
+	}
+
+	contractName, ok := request.Params.Arguments["contractName"].(string)
+	if !ok {
+		return nil, fmt.Errorf("contractName must be a string")
+	}
+
+	compilerVersion, ok := request.Params.Arguments["compilerVersion"].(string)
+	if !ok {
+		return nil, fmt.Errorf("compilerVersion must be a string")
+	}
+
+	standardJSONInput, ok := request.Params.Arguments["standardJsonInput"].(string)
+	if !ok {
+		return nil, fmt.Errorf("standardJsonInput must be a string")
+	}
+	if !json.Valid([]byte(standardJSONInput)) {
+		return nil, fmt.Errorf("standardJsonInput must be valid JSON")
+	}
+
+	timeout, err := verificationTimeout(request.Params.Arguments)
+	if err != nil {
+		return nil, err
+	}
+
+	params := map[string]string{
+		"contractaddress": contractAddress,
+		"contractname":    contractName,
+		"compilerversion": compilerVersion,
+		"sourceCode":      standardJSONInput,
+	}
+	if constructorArguments, ok := request.Params.Arguments["constructorArguments"].(string); ok && constructorArguments != "" {
+		// Etherscan expects the misspelt parameter name and no 0x prefix
+		params["constructorArguements"] = trimHexPrefix(constructorArguments)
+	}
+	if licenseType, ok := request.Params.Arguments["licenseType"].(string); ok && licenseType != "" {
+		params["licenseType"] = licenseType
+	}
+
+	guid, err := client.VerifySourceCode(chainID, params)
+	if err != nil {
+		return nil, err
+	}
+
+	status, err := pollVerification(ctx, request, timeout, func() (*etherscan.VerificationStatus, error) {
+		return client.CheckVerifyStatus(chainID, guid)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	responseJSON, err := json.Marshal(verificationResult(chainID, contractAddress, status))
+	if err != nil {
+		return nil, fmt.Errorf("error serializing verification result: %w", err)
+	}
+
+	return mcp.NewToolResultText(string(responseJSON)), nil
+}
+
+func handleVerifyProxyContract(ctx context.Context, request mcp.CallToolRequest, client etherscan.Explorer) (*mcp.CallToolResult, error) {
+	chainID, ok := request.Params.Arguments["chainID"].(string)
+	if !ok {
+		return nil, fmt.Errorf("chainID must be a string")
+	}
+
+	contractAddress, ok := request.Params.Arguments["contractAddress"].(string)
+	if !ok {
+		return nil, fmt.Errorf("contractAddress must be a string")
+	}
+
+	expectedImplementation, _ := request.Params.Arguments["expectedImplementation"].(string)
+
+	timeout, err := verificationTimeout(request.Params.Arguments)
+	if err != nil {
+		return nil, err
+	}
+
+	guid, err := client.VerifyProxyContract(chainID, contractAddress, expectedImplementation)
+	if err != nil {
+		return nil, err
+	}
+
+	status, err := pollVerification(ctx, request, timeout, func() (*etherscan.VerificationStatus, error) {
+		return client.CheckProxyVerification(chainID, guid)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	responseJSON, err := json.Marshal(verificationResult(chainID, contractAddress, status))
+	if err != nil {
+		return nil, fmt.Errorf("error serializing verification result: %w", err)
+	}
+
+	return mcp.NewToolResultText(string(responseJSON)), nil
+}
+
+func handleCheckVerificationStatus(ctx context.Context, request mcp.CallToolRequest, client etherscan.Explorer) (*mcp.CallToolResult, error) {
+	chainID, ok := request.Params.Arguments["chainID"].(string)
+	if !ok {
+		return nil, fmt.Errorf("chainID must be a string")
+	}
+
+	guid, ok := request.Params.Arguments["guid"].(string)
+	if !ok {
+		return nil, fmt.Errorf("guid must be a string")
+	}
+
+	proxy, _ := request.Params.Arguments["proxy"].(bool)
+
+	var status *etherscan.VerificationStatus
+	var err error
+	if proxy {
+		status, err = client.CheckProxyVerification(chainID, guid)
+	} else {
+		status, err = client.CheckVerifyStatus(chainID, guid)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	responseJSON, err := json.Marshal(verificationResult(chainID, "", status))
+	if err != nil {
+		return nil, fmt.Errorf("error serializing verification result: %w", err)
+	}
+
+	return mcp.NewToolResultText(string(responseJSON)), nil
+}
+
+func handleSendRawTransaction(ctx context.Context, request mcp.CallToolRequest, client etherscan.Explorer, rpcClient *rpc.Client) (*mcp.CallToolResult, error) {
+	chainID, ok := request.Params.Arguments["chainID"].(string)
+	if !ok {
+		return nil, fmt.Errorf("chainID must be a string")
+	}
+
+	signedTx, ok := request.Params.Arguments["signedTransaction"].(string)
+	if !ok {
+		return nil, fmt.Errorf("signedTransaction must be a string")
+	}
+
+	broadcast, _ := request.Params.Arguments["broadcast"].(bool)
+
+	raw, err := hex.DecodeString(trimHexPrefix(strings.TrimSpace(signedTx)))
+	if err != nil {
+		return nil, fmt.Errorf("signedTransaction must be hex encoded: %w", err)
+	}
+
+	tx, err := evm.DecodeTransaction(raw)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode signed transaction: %w", err)
+	}
+
+	// Refuse transactions that could be replayed on, or were signed for, another chain
+	if tx.ChainID == nil {
+		return nil, fmt.Errorf("transaction has no EIP-155 chain ID and could be replayed on any chain; refusing to send")
+	}
+	if tx.ChainID.String() != chainID {
+		return nil, fmt.Errorf("transaction is signed for chain %s but chainID is %s", tx.ChainID, chainID)
+	}
+
+	response := map[string]interface{}{
+		"chainID":     chainID,
+		"transaction": describeTransaction(client, chainID, tx),
+		"broadcast":   broadcast,
+	}
+
+	if broadcast {
+		rawHex := "0x" + hex.EncodeToString(raw)
+		txHash, source, err := fetch(chainID,
+			func() (string, error) { return client.SendRawTransaction(chainID, rawHex) },
+			func() (string, error) { return rpcClient.SendRawTransaction(chainID, rawHex) })
+		if err != nil {
+			return nil, err
+		}
+		response["txHash"] = txHash
+		response["source"] = source
+	} else {
+		response["hint"] = "Review the decoded transaction, then call again with broadcast=true to send it"
+	}
+
+	responseJSON, err := json.Marshal(response)
+	if err != nil {
+		return nil, fmt.Errorf("error serializing transaction: %w", err)
+	}
+
+	return mcp.NewToolResultText(string(responseJSON)), nil
+}
+
+func handleSimulateTransaction(ctx context.Context, request mcp.CallToolRequest, client etherscan.Explorer, rpcClient *rpc.Client) (*mcp.CallToolResult, error) {
+	chainID, ok := request.Params.Arguments["chainID"].(string)
+	if !ok {
+		return nil, fmt.Errorf("chainID must be a string")
+	}
+
+	// State overrides and historical blocks are not available through the Etherscan proxy module
+	if !rpc.IsRPCFallbackChain(chainID) {
+		return nil, fmt.Errorf("simulateTransaction requires an RPC endpoint for chain %s (configure one via RPC_URLS)", chainID)
+	}
+
+	callObject := make(map[string]string)
+	for _, key := range []string{"from", "to", "data"} {
+		if value, ok := request.Params.Arguments[key].(string); ok && value != "" {
+			callObject[key] = value
+		}
+	}
+	for _, key := range []string{"value", "gas"} {
+		quantity, err := hexQuantityArg(request.Params.Arguments, key)
+		if err != nil {
+			return nil, err
+		}
+		if quantity != "" {
+			callObject[key] = quantity
+		}
+	}
+
+	blockNumber, _ := request.Params.Arguments["blockNumber"].(string)
+	if blockNumber == "" {
+		blockNumber = "latest"
+	}
+
+	overridesJSON, _ := request.Params.Arguments["stateOverrides"].(string)
+	overrides, err := parseStateOverrides(overridesJSON)
+	if err != nil {
+		return nil, err
+	}
+
+	to := callObject["to"]
+	data, err := hex.DecodeString(trimHexPrefix(callObject["data"]))
+	if err != nil {
+		return nil, fmt.Errorf("data must be hex encoded: %w", err)
+	}
+
+	response := map[string]interface{}{
+		"chainID":     chainID,
+		"blockNumber": blockNumber,
+	}
+	if len(overrides) > 0 {
+		response["stateOverrides"] = overrides
+	}
+	if decoded := decodeCalldata(client, chainID, to, data); decoded != nil {
+		response["decodedInput"] = decoded
+	}
+
+	returnData, err := rpcClient.Call(chainID, callObject, blockNumber, overrides)
+	if err != nil {
+		revert, err := describeCallFailure(client, chainID, to, err)
+		if err != nil {
+			return nil, fmt.Errorf("simulation failed: %w", err)
+		}
+		response["success"] = false
+		response["revert"] = revert
+	} else {
+		response["success"] = true
+		response["returnData"] = returnData
+
+		if method, _ := findMethod(client, chainID, to, data); method != nil && len(method.Outputs) > 0 {
+			if output, err := hex.DecodeString(trimHexPrefix(returnData)); err == nil {
+				if decoded, err := method.DecodeOutput(output); err == nil {
+					response["decodedOutput"] = decoded
+				}
+			}
+		}
+
+		hexGas, err := rpcClient.EstimateGasAt(chainID, callObject, blockNumber, overrides)
+		if err != nil {
+			response["gasUsedError"] = err.Error()
+		} else if gas, ok := units.ParseBigInt(hexGas); ok {
+			response["gasUsed"] = gas.String()
+		}
+	}
+
+	responseJSON, err := json.Marshal(response)
+	if err != nil {
+		return nil, fmt.Errorf("error serializing simulation result: %w", err)
+	}
+
+	return mcp.NewToolResultText(string(responseJSON)), nil
+}
+
+func handleGetTransactionTrace(ctx context.Context, request mcp.CallToolRequest, client etherscan.Explorer, rpcClient *rpc.Client) (*mcp.CallToolResult, error) {
+	chainID, ok := request.Params.Arguments["chainID"].(string)
+	if !ok {
+		return nil, fmt.Errorf("chainID must be a string")
+	}
+
+	txHash, ok := request.Params.Arguments["txHash"].(string)
+	if !ok {
+		return nil, fmt.Errorf("txHash must be a string")
+	}
+
+	options := traceOptions{maxDepth: defaultTraceDepth}
+	if maxDepthArg, ok := request.Params.Arguments["maxDepth"].(string); ok && maxDepthArg != "" {
+		maxDepth, err := strconv.Atoi(maxDepthArg)
+		if err != nil || maxDepth < 0 {
+			return nil, fmt.Errorf("maxDepth must be a non-negative integer")
+		}
+		options.maxDepth = maxDepth
+	}
+	options.hideStaticCalls, _ = request.Params.Arguments["hideStaticCalls"].(bool)
+	options.decodeArguments, _ = request.Params.Arguments["decodeArguments"].(bool)
+
+	// Neither debug nor trace methods are exposed by the Etherscan proxy module
+	if !rpc.IsRPCFallbackChain(chainID) {
+		return nil, fmt.Errorf("getTransactionTrace requires an RPC endpoint with the debug or trace namespace for chain %s (configure one via RPC_URLS)", chainID)
+	}
+
+	root, tracer, err := traceCallTree(rpcClient, chainID, txHash)
+	if err != nil {
+		return nil, err
+	}
+
+	summary := traceSummary{}
+	summarizeTrace(root, 0, &summary)
+	summary.HiddenCalls = pruneTrace(root, 0, options)
+	decodeTrace(root, newTraceResolver(client, chainID), options)
+
+	response := map[string]interface{}{
+		"chainID": chainID,
+		"txHash":  txHash,
+		"tracer":  tracer,
+		"summary": summary,
+		"trace":   root,
+	}
+
+	responseJSON, err := json.Marshal(response)
+	if err != nil {
+		return nil, fmt.Errorf("error serializing transaction trace: %w", err)
+	}
+
+	return mcp.NewToolResultText(string(responseJSON)), nil
+}
+
+func handleGetStateDiff(ctx context.Context, request mcp.CallToolRequest, client etherscan.Explorer, rpcClient *rpc.Client) (*mcp.CallToolResult, error) {
+	chainID, ok := request.Params.Arguments["chainID"].(string)
+	if !ok {
+		return nil, fmt.Errorf("chainID must be a string")
+	}
+
+	txHash, ok := request.Params.Arguments["txHash"].(string)
+	if !ok {
+		return nil, fmt.Errorf("txHash must be a string")
+	}
+
+	// Replaying a transaction needs the debug or trace namespace, which Etherscan doesn't proxy
+	if !rpc.IsRPCFallbackChain(chainID) {
+		return nil, fmt.Errorf("getStateDiff requires an RPC endpoint with the debug or trace namespace for chain %s (configure one via RPC_URLS)", chainID)
+	}
+
+	diffs, tracer, err := traceStateDiff(rpcClient, chainID, txHash)
+	if err != nil {
+		return nil, err
+	}
+
+	receipt, err := getReceipt(client, rpcClient, chainID, txHash)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get transaction receipt: %w", err)
+	}
+	addTokenChanges(client, rpcClient, chainID, diffs, receipt.Logs)
+	sortStorageChanges(diffs)
+
+	storageWrites := 0
+	for _, account := range diffs {
+		storageWrites += len(account.Storage)
+	}
+
+	response := map[string]interface{}{
+		"chainID":  chainID,
+		"txHash":   txHash,
+		"tracer":   tracer,
+		"accounts": diffs,
+		"summary": map[string]interface{}{
+			"accountsChanged": len(diffs),
+			"storageWrites":   storageWrites,
+			"tokenTransfers":  len(tokenTransfers(receipt.Logs)),
+		},
+	}
+
+	responseJSON, err := json.Marshal(response)
+	if err != nil {
+		return nil, fmt.Errorf("error serializing state diff: %w", err)
+	}
+
+	return mcp.NewToolResultText(string(responseJSON)), nil
+}
+
+func handleExplainTransaction(ctx context.Context, request mcp.CallToolRequest, client etherscan.Explorer, rpcClient *rpc.Client) (*mcp.CallToolResult, error) {
+	chainID, ok := request.Params.Arguments["chainID"].(string)
+	if !ok {
+		return nil, fmt.Errorf("chainID must be a string")
+	}
+
+	txHash, ok := request.Params.Arguments["txHash"].(string)
+	if !ok {
+		return nil, fmt.Errorf("txHash must be a string")
+	}
+
+	explanation, err := explainTransaction(client, rpcClient, chainID, txHash)
+	if err != nil {
+		return nil, err
+	}
+
+	responseJSON, err := json.Marshal(explanation)
+	if err != nil {
+		return nil, fmt.Errorf("error serializing transaction explanation: %w", err)
+	}
+
+	return mcp.NewToolResultText(string(responseJSON)), nil
+}
+
+func handleGetLogs(ctx context.Context, request mcp.CallToolRequest, client etherscan.Explorer, rpcClient *rpc.Client) (*mcp.CallToolResult, error) {
+	chainID, ok := request.Params.Arguments["chainID"].(string)
+	if !ok {
+		return nil, fmt.Errorf("chainID must be a string")
+	}
+
+	fromBlock, ok := request.Params.Arguments["fromBlock"].(string)
+	if !ok {
+		return nil, fmt.Errorf("fromBlock must be a string")
+	}
+
+	toBlock, _ := request.Params.Arguments["toBlock"].(string)
+	if toBlock == "" {
+		toBlock = "latest"
+	}
+
+	filter := logFilter{FromBlock: fromBlock, ToBlock: toBlock}
+	filter.Address, _ = request.Params.Arguments["address"].(string)
+	for i := range filter.Topics {
+		filter.Topics[i], _ = request.Params.Arguments[fmt.Sprintf("topic%d", i)].(string)
+	}
+	if filter.Address == "" && filter.Topics == [4]string{} {
+		return nil, fmt.Errorf("at least one of address or topic0-topic3 is required")
+	}
+
+	logs, source, err := getLogs(client, rpcClient, chainID, filter)
+	if err != nil {
+		return nil, err
+	}
+	if logs == nil {
+		logs = []eventLog{}
+	}
+
+	responseJSON, err := json.Marshal(map[string]interface{}{
+		"logs":   logs,
+		"count":  len(logs),
+		"source": source,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("error serializing logs: %w", err)
+	}
+
+	return mcp.NewToolResultText(string(responseJSON)), nil
+}
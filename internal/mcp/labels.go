@@ -0,0 +1,209 @@
+package mcp
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+	"regexp"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/huahuayu/etherscan-mcp-server/internal/etherscan"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+)
+
+const (
+	// labelCacheTTL is how long name tags are cached; they change rarely, so cache aggressively
+	labelCacheTTL = 24 * time.Hour
+
+	// maxLabelLookups caps the uncached name tag lookups made when enriching a single response
+	maxLabelLookups = 20
+)
+
+// addressPattern matches a complete 20-byte hex address
+var addressPattern = regexp.MustCompile(`^0x[0-9a-fA-F]{40}$`)
+
+// addressLabel is the subset of Etherscan's name tag response used to annotate addresses
+type addressLabel struct {
+	Address          string   `json:"address"`
+	Nametag          string   `json:"nametag"`
+	InternalNametag  string   `json:"internal_nametag,omitempty"`
+	URL              string   `json:"url,omitempty"`
+	ShortDescription string   `json:"shortdescription,omitempty"`
+	Labels           []string `json:"labels,omitempty"`
+	Reputation       string   `json:"reputation,omitempty"`
+}
+
+// labelEntry is a cached lookup result; label is nil when the address has no name tag
+type labelEntry struct {
+	label   *addressLabel
+	expires time.Time
+}
+
+// labelCache caches name tag lookups per chain and address, including addresses without a tag
+type labelCache struct {
+	mu          sync.Mutex
+	entries     map[string]labelEntry
+	unavailable map[string]time.Time // chains where the explorer or API plan doesn't offer the endpoint
+}
+
+var labels = &labelCache{
+	entries:     make(map[string]labelEntry),
+	unavailable: make(map[string]time.Time),
+}
+
+// lookup returns the name tag of an address, using the cache when possible
+func (c *labelCache) lookup(client etherscan.Explorer, chainID, address string) (*addressLabel, error) {
+	key := chainID + ":" + strings.ToLower(address)
+
+	c.mu.Lock()
+	if entry, ok := c.entries[key]; ok && time.Now().Before(entry.expires) {
+		c.mu.Unlock()
+		return entry.label, nil
+	}
+	if until, ok := c.unavailable[chainID]; ok && time.Now().Before(until) {
+		c.mu.Unlock()
+		return nil, fmt.Errorf("%w: name tags are not available on this chain with the configured explorer and API key", etherscan.ErrUnsupportedEndpoint)
+	}
+	c.mu.Unlock()
+
+	result, err := client.GetAddressTag(chainID, address)
+	if err != nil {
+		if etherscan.IsProEndpointError(err) || etherscan.IsUnavailableError(err) {
+			c.mu.Lock()
+			c.unavailable[chainID] = time.Now().Add(labelCacheTTL)
+			c.mu.Unlock()
+		}
+		return nil, err
+	}
+
+	var tags []addressLabel
+	if err := json.Unmarshal(result, &tags); err != nil {
+		return nil, fmt.Errorf("failed to parse name tag: %w", err)
+	}
+
+	var label *addressLabel
+	if len(tags) > 0 && (tags[0].Nametag != "" || len(tags[0].Labels) > 0) {
+		label = &tags[0]
+	}
+
+	c.mu.Lock()
+	c.entries[key] = labelEntry{label: label, expires: time.Now().Add(labelCacheTTL)}
+	c.mu.Unlock()
+
+	return label, nil
+}
+
+// collectAddresses gathers the distinct addresses appearing as values anywhere in a decoded JSON document
+func collectAddresses(value interface{}, seen map[string]string) {
+	switch v := value.(type) {
+	case map[string]interface{}:
+		for _, item := range v {
+			collectAddresses(item, seen)
+		}
+	case []interface{}:
+		for _, item := range v {
+			collectAddresses(item, seen)
+		}
+	case string:
+		if addressPattern.MatchString(v) {
+			if _, ok := seen[strings.ToLower(v)]; !ok {
+				seen[strings.ToLower(v)] = v
+			}
+		}
+	}
+}
+
+// enrichWithLabels annotates the addresses in a JSON tool output with their name tags.
+// Objects get an "addressLabels" field; other values are wrapped as {"result": ..., "addressLabels": ...}.
+func enrichWithLabels(client etherscan.Explorer, chainID, output string) string {
+	var document interface{}
+	if err := json.Unmarshal([]byte(output), &document); err != nil {
+		return output
+	}
+
+	addresses := make(map[string]string)
+	collectAddresses(document, addresses)
+	if len(addresses) == 0 {
+		return output
+	}
+
+	// Look addresses up in a stable order so that the same output gets the same labels under the lookup cap
+	keys := make([]string, 0, len(addresses))
+	for key := range addresses {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	found := make(map[string]*addressLabel)
+	for i, key := range keys {
+		if i >= maxLabelLookups {
+			break
+		}
+		address := addresses[key]
+		label, err := labels.lookup(client, chainID, address)
+		if err != nil {
+			log.Printf("Name tag lookup failed for %s on chain %s: %v", address, chainID, err)
+			if etherscan.IsProEndpointError(err) || etherscan.IsUnavailableError(err) {
+				break
+			}
+			continue
+		}
+		if label != nil {
+			found[key] = label
+		}
+	}
+
+	var enriched interface{}
+	if object, ok := document.(map[string]interface{}); ok {
+		object["addressLabels"] = found
+		enriched = object
+	} else {
+		enriched = map[string]interface{}{
+			"result":        document,
+			"addressLabels": found,
+		}
+	}
+
+	enrichedJSON, err := json.Marshal(enriched)
+	if err != nil {
+		return output
+	}
+	return string(enrichedJSON)
+}
+
+// withLabels wraps a tool handler so that its output is annotated with address name tags
+// when the caller sets includeLabels
+func withLabels(client etherscan.Explorer, handler server.ToolHandlerFunc) server.ToolHandlerFunc {
+	return func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		result, err := handler(ctx, request)
+		if err != nil || result == nil || result.IsError {
+			return result, err
+		}
+
+		includeLabels, _ := request.Params.Arguments["includeLabels"].(bool)
+		chainID, _ := request.Params.Arguments["chainID"].(string)
+		if !includeLabels || chainID == "" {
+			return result, nil
+		}
+
+		for i, content := range result.Content {
+			if text, ok := content.(mcp.TextContent); ok {
+				text.Text = enrichWithLabels(client, chainID, text.Text)
+				result.Content[i] = text
+			}
+		}
+		return result, nil
+	}
+}
+
+// includeLabelsOption is the tool parameter that enables name tag enrichment
+func includeLabelsOption() mcp.ToolOption {
+	return mcp.WithBoolean("includeLabels",
+		mcp.Description("Annotate addresses in the output with their Etherscan name tags and labels (default: false)"),
+	)
+}
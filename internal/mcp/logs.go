@@ -0,0 +1,94 @@
+package mcp
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/huahuayu/etherscan-mcp-server/internal/etherscan"
+	"github.com/huahuayu/etherscan-mcp-server/internal/rpc"
+)
+
+// eventLog is an event log in the fields shared by Etherscan's logs module and eth_getLogs
+type eventLog struct {
+	Address          string   `json:"address"`
+	Topics           []string `json:"topics"`
+	Data             string   `json:"data"`
+	BlockNumber      string   `json:"blockNumber"`
+	TransactionHash  string   `json:"transactionHash"`
+	TransactionIndex string   `json:"transactionIndex"`
+	LogIndex         string   `json:"logIndex"`
+}
+
+// logFilter selects event logs by block range, emitting contract and topics
+type logFilter struct {
+	FromBlock string
+	ToBlock   string
+	Address   string
+	Topics    [4]string // empty positions match any topic
+}
+
+// etherscanParams returns the address and topics as logs/getLogs parameters, requiring every given topic to match
+func (f logFilter) etherscanParams() map[string]string {
+	params := make(map[string]string)
+	if f.Address != "" {
+		params["address"] = f.Address
+	}
+	for i, topic := range f.Topics {
+		if topic == "" {
+			continue
+		}
+		params[fmt.Sprintf("topic%d", i)] = topic
+		for j := i + 1; j < len(f.Topics); j++ {
+			if f.Topics[j] != "" {
+				params[fmt.Sprintf("topic%d_%d_opr", i, j)] = "and"
+			}
+		}
+	}
+	return params
+}
+
+// rpcTopics returns the topics as an eth_getLogs topic list, with trailing wildcards dropped
+func (f logFilter) rpcTopics() []interface{} {
+	topics := make([]interface{}, len(f.Topics))
+	for i, topic := range f.Topics {
+		if topic != "" {
+			topics[i] = topic
+		}
+	}
+
+	last := len(topics)
+	for last > 0 && topics[last-1] == nil {
+		last--
+	}
+	return topics[:last]
+}
+
+// getLogs gets the logs matching a filter from the chain's backend, returning the backend that answered
+func getLogs(client etherscan.Explorer, rpcClient *rpc.Client, chainID string, filter logFilter) ([]eventLog, string, error) {
+	result, source, err := fetch(chainID,
+		func() (json.RawMessage, error) {
+			return client.GetLogs(chainID, filter.FromBlock, filter.ToBlock, filter.etherscanParams())
+		},
+		func() (json.RawMessage, error) {
+			return rpcClient.GetLogs(chainID, filter.FromBlock, filter.ToBlock, filter.Address, filter.rpcTopics())
+		})
+	if err != nil {
+		return nil, "", err
+	}
+
+	var logs []eventLog
+	if err := json.Unmarshal(result, &logs); err != nil {
+		return nil, "", fmt.Errorf("failed to parse logs: %w", err)
+	}
+
+	// Etherscan reports zero indexes as a bare "0x"
+	for i := range logs {
+		for _, quantity := range []*string{&logs[i].BlockNumber, &logs[i].TransactionIndex, &logs[i].LogIndex} {
+			if *quantity == "0x" {
+				*quantity = "0x0"
+			}
+		}
+	}
+
+	return logs, source, nil
+}
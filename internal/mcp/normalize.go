@@ -0,0 +1,244 @@
+package mcp
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"regexp"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/huahuayu/etherscan-mcp-server/internal/chains"
+	"github.com/huahuayu/etherscan-mcp-server/internal/etherscan"
+	"github.com/huahuayu/etherscan-mcp-server/internal/rpc"
+	"github.com/huahuayu/etherscan-mcp-server/internal/units"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+)
+
+// normalizeByDefault controls whether tool outputs are normalized when the caller doesn't set raw
+var normalizeByDefault = true
+
+// SetNormalization enables or disables response normalization for calls that don't set raw
+func SetNormalization(enabled bool) {
+	normalizeByDefault = enabled
+}
+
+var (
+	// quantityKeys hold integer quantities that JSON-RPC returns as hex
+	quantityKeys = keySet("blockNumber", "number", "nonce", "transactionIndex", "logIndex", "status", "type",
+		"chainId", "gas", "gasLimit", "gasUsed", "cumulativeGasUsed", "size", "difficulty", "totalDifficulty",
+		"blobGasUsed", "excessBlobGas", "l1GasUsed", "l1Fee", "l1GasPrice")
+
+	// weiKeys hold native currency amounts in wei
+	weiKeys = keySet("value", "balance", "blockReward", "totalRewards")
+
+	// gweiKeys hold gas prices in wei that are easier to read in gwei
+	gweiKeys = keySet("gasPrice", "effectiveGasPrice", "maxFeePerGas", "maxPriorityFeePerGas", "baseFeePerGas",
+		"blobGasPrice", "maxFeePerBlobGas")
+
+	// timestampKeys hold Unix timestamps in seconds
+	timestampKeys = keySet("timestamp", "timeStamp")
+
+	// opaqueKeys hold values whose fields are not amounts (decoded ABI values, raw storage, source files)
+	opaqueKeys = keySet("arguments", "decodedOutput", "stateOverrides", "storage", "settings", "files", "addressLabels")
+
+	// unnormalizedTools return code, ABIs, slots or labels rather than amounts
+	unnormalizedTools = keySet("listChains", "getChainCapabilities", "getContractABI", "getContractSourceCode", "getContractCode", "getStorageAt",
+		"computeStorageSlot", "resolveProxy", "getAddressLabel", "verifyContractSource", "verifyProxyContract",
+		"checkVerificationStatus")
+
+	// tokenAmountTools report a balance of the token given by their contractAddress argument
+	tokenAmountTools = keySet("getTokenBalance")
+)
+
+// hexQuantityPattern matches a hex integer no wider than 256 bits
+var hexQuantityPattern = regexp.MustCompile(`^0x[0-9a-fA-F]{1,64}$`)
+
+func keySet(keys ...string) map[string]bool {
+	set := make(map[string]bool, len(keys))
+	for _, key := range keys {
+		set[key] = true
+	}
+	return set
+}
+
+// decimalsCache caches ERC20 decimals per chain and token; they never change
+type decimalsCache struct {
+	mu      sync.Mutex
+	entries map[string]int
+}
+
+var tokenDecimalsCache = &decimalsCache{entries: make(map[string]int)}
+
+// lookup returns the decimals of a token, reading decimals() on a cache miss
+func (c *decimalsCache) lookup(client etherscan.Explorer, rpcClient *rpc.Client, chainID, token string) (int, bool) {
+	key := chainID + ":" + strings.ToLower(token)
+
+	c.mu.Lock()
+	decimals, ok := c.entries[key]
+	c.mu.Unlock()
+	if ok {
+		return decimals, true
+	}
+
+	decimals, err := tokenDecimals(client, rpcClient, chainID, token)
+	if err != nil {
+		return 0, false
+	}
+
+	c.mu.Lock()
+	c.entries[key] = decimals
+	c.mu.Unlock()
+	return decimals, true
+}
+
+// normalizer rewrites a JSON tool output: hex quantities become decimal, and wei amounts, gas prices,
+// token amounts and timestamps get readable companion fields
+type normalizer struct {
+	// nativeDecimals are the decimals of the chain's native currency
+	nativeDecimals int
+	// tokenAmounts marks top-level balances as token amounts rather than native currency
+	tokenAmounts bool
+	// tokenDecimals are the decimals of those token amounts, nil when they couldn't be read
+	tokenDecimals *int
+}
+
+func (n *normalizer) normalize(value interface{}, topLevel bool) interface{} {
+	switch v := value.(type) {
+	case map[string]interface{}:
+		n.normalizeObject(v, topLevel)
+		return v
+	case []interface{}:
+		for i, item := range v {
+			v[i] = n.normalize(item, false)
+		}
+		return v
+	}
+	return value
+}
+
+func (n *normalizer) normalizeObject(object map[string]interface{}, topLevel bool) {
+	// Etherscan token transfer entries carry their own decimals; NFT transfers have no amount.
+	// Token amounts of unknown decimals are left unformatted rather than scaled as native currency.
+	decimals, knownDecimals := n.nativeDecimals, true
+	if tokenDecimal, ok := object["tokenDecimal"].(string); ok {
+		var err error
+		decimals, err = strconv.Atoi(tokenDecimal)
+		knownDecimals = err == nil
+	} else if topLevel && n.tokenAmounts {
+		knownDecimals = n.tokenDecimals != nil
+		if knownDecimals {
+			decimals = *n.tokenDecimals
+		}
+	}
+	_, isNFT := object["tokenID"]
+
+	for key, item := range object {
+		if opaqueKeys[key] {
+			continue
+		}
+
+		text, isString := item.(string)
+		if !isString {
+			object[key] = n.normalize(item, false)
+			continue
+		}
+
+		known := quantityKeys[key] || weiKeys[key] || gweiKeys[key] || timestampKeys[key]
+		if known && hexQuantityPattern.MatchString(text) {
+			if quantity, ok := units.ParseBigInt(text); ok {
+				text = quantity.String()
+				object[key] = text
+			}
+		}
+
+		quantity, ok := units.ParseBigInt(text)
+		if !ok {
+			continue
+		}
+
+		switch {
+		case weiKeys[key] && !isNFT && knownDecimals:
+			if _, exists := object[key+"Formatted"]; !exists {
+				object[key+"Formatted"] = units.FormatUnits(quantity, decimals)
+			}
+		case gweiKeys[key]:
+			if _, exists := object[key+"Gwei"]; !exists {
+				object[key+"Gwei"] = units.FormatUnits(quantity, gweiDecimals)
+			}
+		case timestampKeys[key]:
+			if _, exists := object[key+"ISO"]; !exists && quantity.IsInt64() {
+				object[key+"ISO"] = time.Unix(quantity.Int64(), 0).UTC().Format(time.RFC3339)
+			}
+		}
+	}
+}
+
+// normalizeOutput normalizes a JSON tool output, returning non-JSON output unchanged
+func (n *normalizer) normalizeOutput(output string) string {
+	decoder := json.NewDecoder(strings.NewReader(output))
+	decoder.UseNumber()
+
+	var document interface{}
+	if err := decoder.Decode(&document); err != nil || decoder.More() {
+		return output
+	}
+
+	var buffer bytes.Buffer
+	encoder := json.NewEncoder(&buffer)
+	encoder.SetEscapeHTML(false)
+	if err := encoder.Encode(n.normalize(document, true)); err != nil {
+		return output
+	}
+	return strings.TrimSuffix(buffer.String(), "\n")
+}
+
+// withNormalization wraps a tool handler so that its output is normalized unless the caller sets raw
+func withNormalization(client etherscan.Explorer, rpcClient *rpc.Client, toolName string, handler server.ToolHandlerFunc) server.ToolHandlerFunc {
+	return func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		result, err := handler(ctx, request)
+		if err != nil || result == nil || result.IsError {
+			return result, err
+		}
+
+		normalize := normalizeByDefault
+		if raw, ok := request.Params.Arguments["raw"].(bool); ok {
+			normalize = !raw
+		}
+		if !normalize {
+			return result, nil
+		}
+
+		chainID, _ := request.Params.Arguments["chainID"].(string)
+		n := &normalizer{nativeDecimals: chains.Native(chainID).Decimals, tokenAmounts: tokenAmountTools[toolName]}
+		if n.tokenAmounts {
+			contractAddress, _ := request.Params.Arguments["contractAddress"].(string)
+			if decimals, ok := tokenDecimalsCache.lookup(client, rpcClient, chainID, contractAddress); ok {
+				n.tokenDecimals = &decimals
+			}
+		}
+
+		for i, content := range result.Content {
+			if text, ok := content.(mcp.TextContent); ok {
+				text.Text = n.normalizeOutput(text.Text)
+				result.Content[i] = text
+			}
+		}
+		return result, nil
+	}
+}
+
+// withRawOption adds the raw parameter that disables normalization for a call
+func withRawOption(tool mcp.Tool) mcp.Tool {
+	if tool.InputSchema.Properties == nil {
+		tool.InputSchema.Properties = make(map[string]interface{})
+	}
+	tool.InputSchema.Properties["raw"] = map[string]interface{}{
+		"type":        "boolean",
+		"description": "Return the upstream values unchanged instead of adding decimal, gwei, token unit and ISO-8601 fields (default: false)",
+	}
+	return tool
+}
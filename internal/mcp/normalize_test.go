@@ -0,0 +1,108 @@
+package mcp
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+)
+
+func TestNormalizeOutput(t *testing.T) {
+	six := 6
+	tests := []struct {
+		name       string
+		normalizer normalizer
+		input      string
+		want       string
+	}{
+		{
+			name:       "hex quantities and gas prices",
+			normalizer: normalizer{nativeDecimals: 18},
+			input:      `{"blockNumber":"0x10","gasPrice":"0x3b9aca00","hash":"0xabcd"}`,
+			want:       `{"blockNumber":"16","gasPrice":"1000000000","gasPriceGwei":"1","hash":"0xabcd"}`,
+		},
+		{
+			name:       "native balance",
+			normalizer: normalizer{nativeDecimals: 18},
+			input:      `{"balance":"1500000000000000000","source":"etherscan"}`,
+			want:       `{"balance":"1500000000000000000","balanceFormatted":"1.5","source":"etherscan"}`,
+		},
+		{
+			name:       "token balance with known decimals",
+			normalizer: normalizer{nativeDecimals: 18, tokenAmounts: true, tokenDecimals: &six},
+			input:      `{"balance":"2500000"}`,
+			want:       `{"balance":"2500000","balanceFormatted":"2.5"}`,
+		},
+		{
+			name:       "token balance with unknown decimals",
+			normalizer: normalizer{nativeDecimals: 18, tokenAmounts: true},
+			input:      `{"balance":"2500000"}`,
+			want:       `{"balance":"2500000"}`,
+		},
+		{
+			name:       "token transfers carry their own decimals",
+			normalizer: normalizer{nativeDecimals: 18},
+			input:      `[{"value":"1230000","tokenDecimal":"6"},{"value":"1","tokenDecimal":""},{"value":"1","tokenID":"7"}]`,
+			want:       `[{"tokenDecimal":"6","value":"1230000","valueFormatted":"1.23"},{"tokenDecimal":"","value":"1"},{"tokenID":"7","value":"1"}]`,
+		},
+		{
+			name:       "timestamps",
+			normalizer: normalizer{nativeDecimals: 18},
+			input:      `{"timestamp":"0x5f5e100"}`,
+			want:       `{"timestamp":"100000000","timestampISO":"1973-03-03T09:46:40Z"}`,
+		},
+		{
+			name:       "opaque values are left alone",
+			normalizer: normalizer{nativeDecimals: 18},
+			input:      `{"arguments":{"value":"0x10"},"storage":{"0x0":"0x1"}}`,
+			want:       `{"arguments":{"value":"0x10"},"storage":{"0x0":"0x1"}}`,
+		},
+		{
+			name:       "existing companion fields are kept",
+			normalizer: normalizer{nativeDecimals: 18},
+			input:      `{"value":"1000000000000000000","valueFormatted":"1 ETH"}`,
+			want:       `{"value":"1000000000000000000","valueFormatted":"1 ETH"}`,
+		},
+		{
+			name:       "numbers keep their precision",
+			normalizer: normalizer{nativeDecimals: 18},
+			input:      `{"count":12345678901234567890}`,
+			want:       `{"count":12345678901234567890}`,
+		},
+		{
+			name:       "non-JSON output is unchanged",
+			normalizer: normalizer{nativeDecimals: 18},
+			input:      `not json`,
+			want:       `not json`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := tt.normalizer.normalizeOutput(tt.input)
+			if !jsonEqual(t, got, tt.want) {
+				t.Errorf("got %s, want %s", got, tt.want)
+			}
+		})
+	}
+}
+
+// jsonEqual compares two outputs as JSON documents with exact numbers, falling back to string comparison
+func jsonEqual(t *testing.T, got, want string) bool {
+	t.Helper()
+	gotValue, gotErr := decodeExact(got)
+	wantValue, wantErr := decodeExact(want)
+	if gotErr != nil || wantErr != nil {
+		return got == want
+	}
+	gotJSON, _ := json.Marshal(gotValue)
+	wantJSON, _ := json.Marshal(wantValue)
+	return string(gotJSON) == string(wantJSON)
+}
+
+func decodeExact(document string) (interface{}, error) {
+	decoder := json.NewDecoder(strings.NewReader(document))
+	decoder.UseNumber()
+	var value interface{}
+	err := decoder.Decode(&value)
+	return value, err
+}
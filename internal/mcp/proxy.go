@@ -0,0 +1,227 @@
+package mcp
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"log"
+	"strings"
+
+	"github.com/huahuayu/etherscan-mcp-server/internal/etherscan"
+	"github.com/huahuayu/etherscan-mcp-server/internal/evm"
+	"github.com/huahuayu/etherscan-mcp-server/internal/rpc"
+)
+
+// Bytecode markers used to recognize proxies that don't use standard storage slots
+const (
+	// EIP-1167 minimal proxy runtime code around the 20-byte implementation address
+	minimalProxyPrefix = "363d3d373d3d3d363d73"
+	minimalProxySuffix = "5af43d82803e903d91602b57fd5bf3"
+
+	// Gnosis Safe proxies answer masterCopy() from their runtime code and keep the singleton in slot 0
+	safeMasterCopySelector = "0xa619486e"
+
+	// UUPS implementations expose proxiableUUID()
+	proxiableUUIDSelector = "0x52d1902d"
+
+	// Beacons expose implementation()
+	selectorImplementation = "0x5c60da1b"
+)
+
+// proxyInfo describes how a contract delegates to its implementation
+type proxyInfo struct {
+	Address        string `json:"address"`
+	IsProxy        bool   `json:"isProxy"`
+	Type           string `json:"type,omitempty"`
+	Implementation string `json:"implementation,omitempty"`
+	Admin          string `json:"admin,omitempty"`
+	Beacon         string `json:"beacon,omitempty"`
+	DetectedBy     string `json:"detectedBy,omitempty"`
+}
+
+// resolveProxy detects the proxy pattern of a contract from its bytecode and storage, falling back
+// to the Implementation field Etherscan reports for verified proxies
+func resolveProxy(client etherscan.Explorer, rpcClient *rpc.Client, chainID, address string) (*proxyInfo, error) {
+	info := &proxyInfo{Address: address}
+
+	code, _, err := getCode(client, rpcClient, chainID, address, "latest")
+	if err != nil {
+		return nil, fmt.Errorf("failed to get contract code: %w", err)
+	}
+	code = strings.ToLower(trimHexPrefix(code))
+	if code == "" {
+		return nil, fmt.Errorf("no contract code at %s", address)
+	}
+
+	// EIP-1167 minimal proxy: the implementation is embedded in the bytecode
+	if strings.HasPrefix(code, minimalProxyPrefix) && len(code) >= len(minimalProxyPrefix)+40+len(minimalProxySuffix) {
+		implementation := code[len(minimalProxyPrefix) : len(minimalProxyPrefix)+40]
+		if strings.HasPrefix(code[len(minimalProxyPrefix)+40:], minimalProxySuffix) {
+			info.setProxy("eip1167", "0x"+implementation, "bytecode")
+			return info, nil
+		}
+	}
+
+	readAddressSlot := func(name string) string {
+		slot, _ := evm.ParseSlot(name)
+		value, _, err := getStorageAt(client, rpcClient, chainID, address, slot, "latest")
+		if err != nil {
+			log.Printf("Failed to read %s slot of %s: %v", name, address, err)
+			return ""
+		}
+		implementation, _ := evm.AddressFromWord(value)
+		return implementation
+	}
+
+	// EIP-1967: transparent proxies also set the admin slot, UUPS implementations expose proxiableUUID()
+	if implementation := readAddressSlot("eip1967.implementation"); implementation != "" {
+		proxyType := "eip1967"
+		if admin := readAddressSlot("eip1967.admin"); admin != "" {
+			proxyType = "transparent"
+			info.Admin = admin
+		} else if implCode, _, err := getCode(client, rpcClient, chainID, implementation, "latest"); err == nil &&
+			pushesSelector(decodeHexData(implCode), proxiableUUIDSelector) {
+			proxyType = "uups"
+		}
+		info.setProxy(proxyType, implementation, "storage")
+		return info, nil
+	}
+
+	// EIP-1967 beacon proxy: ask the beacon for the implementation
+	if beacon := readAddressSlot("eip1967.beacon"); beacon != "" {
+		info.Beacon = beacon
+		if hexValue, err := callContract(client, rpcClient, chainID, beacon, selectorImplementation); err == nil {
+			implementation, _ := evm.AddressFromWord(hexValue)
+			info.setProxy("beacon", implementation, "storage")
+			return info, nil
+		}
+		info.setProxy("beacon", "", "storage")
+		return info, nil
+	}
+
+	// EIP-1822 (original UUPS) and legacy ZeppelinOS slots
+	if implementation := readAddressSlot("eip1822.proxiable"); implementation != "" {
+		info.setProxy("eip1822", implementation, "storage")
+		return info, nil
+	}
+	if implementation := readAddressSlot("zeppelinos.implementation"); implementation != "" {
+		info.setProxy("zeppelinos", implementation, "storage")
+		return info, nil
+	}
+
+	// Gnosis Safe proxy: the singleton address lives in slot 0 and must be a contract
+	if pushesSelector(decodeHexData(code), safeMasterCopySelector) {
+		if implementation := readAddressSlot("0"); implementation != "" {
+			if singletonCode, _, err := getCode(client, rpcClient, chainID, implementation, "latest"); err == nil &&
+				trimHexPrefix(singletonCode) != "" {
+				info.setProxy("gnosis-safe", implementation, "bytecode")
+				return info, nil
+			}
+		}
+	}
+
+	// Finally, trust Etherscan's own proxy detection for verified contracts
+	if result, err := client.GetContractSourceCode(chainID, address); err == nil {
+		var sources []struct {
+			Proxy          string `json:"Proxy"`
+			Implementation string `json:"Implementation"`
+		}
+		if err := json.Unmarshal(result, &sources); err == nil && len(sources) > 0 &&
+			sources[0].Proxy == "1" && sources[0].Implementation != "" {
+			info.setProxy("etherscan", sources[0].Implementation, "etherscan")
+			return info, nil
+		}
+	}
+
+	return info, nil
+}
+
+// pushesSelector reports whether runtime code pushes a function selector, either with PUSH4 as Solidity
+// dispatchers do or left-aligned in a PUSH32 as the Safe proxy does. The code is walked instruction by
+// instruction so that selector bytes inside other PUSH data or at odd offsets don't match.
+func pushesSelector(code []byte, selector string) bool {
+	want := decodeHexData(selector)
+	for i := 0; i < len(code); i++ {
+		op := code[i]
+		if op < 0x60 || op > 0x7f {
+			continue
+		}
+
+		size := int(op-0x60) + 1
+		if i+1+size > len(code) {
+			return false
+		}
+		data := code[i+1 : i+1+size]
+		switch size {
+		case 4:
+			if bytes.Equal(data, want) {
+				return true
+			}
+		case 32:
+			if bytes.Equal(data[:4], want) && bytes.Count(data[4:], []byte{0}) == 28 {
+				return true
+			}
+		}
+		i += size
+	}
+	return false
+}
+
+// setProxy records a detected proxy pattern
+func (p *proxyInfo) setProxy(proxyType, implementation, detectedBy string) {
+	p.IsProxy = true
+	p.Type = proxyType
+	p.Implementation = implementation
+	p.DetectedBy = detectedBy
+}
+
+// mergeABIs combines a proxy ABI with its implementation ABI, dropping duplicate entries.
+// Proxy entries come first so that admin functions stay visible.
+func mergeABIs(proxyABI, implementationABI string) (string, error) {
+	var merged []map[string]interface{}
+	seen := make(map[string]bool)
+
+	for _, abi := range []string{proxyABI, implementationABI} {
+		var entries []map[string]interface{}
+		if err := json.Unmarshal([]byte(abi), &entries); err != nil {
+			return "", fmt.Errorf("failed to parse ABI: %w", err)
+		}
+		for _, entry := range entries {
+			key := abiEntryKey(entry)
+			if seen[key] {
+				continue
+			}
+			seen[key] = true
+			merged = append(merged, entry)
+		}
+	}
+
+	mergedJSON, err := json.Marshal(merged)
+	if err != nil {
+		return "", fmt.Errorf("error serializing merged ABI: %w", err)
+	}
+	return string(mergedJSON), nil
+}
+
+// abiEntryKey identifies an ABI entry by kind, name and input types
+func abiEntryKey(entry map[string]interface{}) string {
+	entryType, _ := entry["type"].(string)
+	name, _ := entry["name"].(string)
+
+	// Only one constructor, fallback and receive entry make sense in a merged ABI
+	if entryType == "constructor" || entryType == "fallback" || entryType == "receive" {
+		return entryType
+	}
+
+	var inputTypes []string
+	if inputs, ok := entry["inputs"].([]interface{}); ok {
+		for _, input := range inputs {
+			if param, ok := input.(map[string]interface{}); ok {
+				paramType, _ := param["type"].(string)
+				inputTypes = append(inputTypes, paramType)
+			}
+		}
+	}
+
+	return fmt.Sprintf("%s:%s(%s)", entryType, name, strings.Join(inputTypes, ","))
+}
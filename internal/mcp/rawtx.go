@@ -0,0 +1,64 @@
+package mcp
+
+import (
+	"encoding/hex"
+	"math/big"
+
+	"github.com/huahuayu/etherscan-mcp-server/internal/chains"
+	"github.com/huahuayu/etherscan-mcp-server/internal/etherscan"
+	"github.com/huahuayu/etherscan-mcp-server/internal/evm"
+	"github.com/huahuayu/etherscan-mcp-server/internal/units"
+)
+
+// describeTransaction builds a readable view of a decoded signed transaction
+func describeTransaction(client etherscan.Explorer, chainID string, tx *evm.Transaction) map[string]interface{} {
+	description := map[string]interface{}{
+		"type":           tx.Type,
+		"hash":           tx.Hash,
+		"nonce":          tx.Nonce.String(),
+		"value":          tx.Value.String(),
+		"valueFormatted": units.FormatUnits(tx.Value, chains.Native(chainID).Decimals),
+		"gas":            tx.Gas.String(),
+		"input":          "0x" + hex.EncodeToString(tx.Data),
+	}
+
+	if tx.ChainID != nil {
+		description["chainId"] = tx.ChainID.String()
+	}
+	if tx.To != "" {
+		description["to"] = tx.To
+	} else {
+		description["contractCreation"] = true
+	}
+
+	fees := map[string]*big.Int{
+		"gasPrice":             tx.GasPrice,
+		"maxFeePerGas":         tx.MaxFeePerGas,
+		"maxPriorityFeePerGas": tx.MaxPriorityFeePerGas,
+		"maxFeePerBlobGas":     tx.MaxFeePerBlobGas,
+	}
+	for name, fee := range fees {
+		if fee != nil {
+			description[name] = fee.String()
+			description[name+"Gwei"] = units.FormatUnits(fee, gweiDecimals)
+		}
+	}
+
+	if tx.AccessListSize > 0 {
+		description["accessListEntries"] = tx.AccessListSize
+	}
+	if len(tx.BlobHashes) > 0 {
+		description["blobVersionedHashes"] = tx.BlobHashes
+	}
+	if tx.Type == evm.SetCodeTxType {
+		description["authorizations"] = tx.AuthorizationCount
+	}
+
+	if tx.To != "" {
+		if decoded := decodeCalldata(client, chainID, tx.To, tx.Data); decoded != nil {
+			description["decodedInput"] = decoded
+		}
+	}
+
+	return description
+}
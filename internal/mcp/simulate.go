@@ -0,0 +1,145 @@
+package mcp
+
+import (
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/huahuayu/etherscan-mcp-server/internal/abi"
+	"github.com/huahuayu/etherscan-mcp-server/internal/etherscan"
+	"github.com/huahuayu/etherscan-mcp-server/internal/evm"
+	"github.com/huahuayu/etherscan-mcp-server/internal/rpc"
+	"github.com/huahuayu/etherscan-mcp-server/internal/units"
+)
+
+// JSON-RPC error codes for requests the node could not process
+const (
+	rpcMethodNotFound = -32601
+	rpcInvalidParams  = -32602
+)
+
+// stateOverride is the per-address state override accepted by the simulateTransaction tool
+type stateOverride struct {
+	Balance   string            `json:"balance"`
+	Nonce     string            `json:"nonce"`
+	Code      string            `json:"code"`
+	State     map[string]string `json:"state"`
+	StateDiff map[string]string `json:"stateDiff"`
+	Storage   map[string]string `json:"storage"` // alias of stateDiff
+}
+
+// stateOverridesDescription documents the stateOverrides argument
+const stateOverridesDescription = `JSON object of state overrides keyed by address, e.g. {"0x...":{"balance":"1000000000000000000","code":"0x...","storage":{"0x0":"0x1"}}}. ` +
+	`Fields: balance and nonce (decimal or hex), code (hex), storage or stateDiff (slot → value, merged with existing storage), state (slot → value, replaces all storage). ` +
+	`Slots may be numbers or well-known names such as eip1967.implementation`
+
+// parseStateOverrides converts the stateOverrides argument into the eth_call state override set
+func parseStateOverrides(overridesJSON string) (map[string]interface{}, error) {
+	if strings.TrimSpace(overridesJSON) == "" {
+		return nil, nil
+	}
+
+	var parsed map[string]stateOverride
+	if err := json.Unmarshal([]byte(overridesJSON), &parsed); err != nil {
+		return nil, fmt.Errorf("invalid stateOverrides: %w", err)
+	}
+
+	overrides := make(map[string]interface{}, len(parsed))
+	for address, override := range parsed {
+		if !addressPattern.MatchString(address) {
+			return nil, fmt.Errorf("invalid stateOverrides address: %s", address)
+		}
+
+		entry := make(map[string]interface{})
+		for name, value := range map[string]string{"balance": override.Balance, "nonce": override.Nonce} {
+			if value == "" {
+				continue
+			}
+			quantity, ok := units.ParseBigInt(value)
+			if !ok || quantity.Sign() < 0 {
+				return nil, fmt.Errorf("invalid %s override for %s: %s", name, address, value)
+			}
+			entry[name] = units.ToHex(quantity)
+		}
+		if override.Code != "" {
+			entry["code"] = override.Code
+		}
+
+		if override.State != nil && (override.StateDiff != nil || override.Storage != nil) {
+			return nil, fmt.Errorf("state and stateDiff/storage overrides for %s are mutually exclusive", address)
+		}
+		if override.State != nil {
+			state, err := storageOverride(override.State)
+			if err != nil {
+				return nil, err
+			}
+			entry["state"] = state
+		}
+		if override.StateDiff != nil || override.Storage != nil {
+			merged := make(map[string]string)
+			for slot, value := range override.Storage {
+				merged[slot] = value
+			}
+			for slot, value := range override.StateDiff {
+				merged[slot] = value
+			}
+			stateDiff, err := storageOverride(merged)
+			if err != nil {
+				return nil, err
+			}
+			entry["stateDiff"] = stateDiff
+		}
+
+		overrides[address] = entry
+	}
+	return overrides, nil
+}
+
+// storageOverride normalizes storage slots and values to 32-byte hex words
+func storageOverride(slots map[string]string) (map[string]string, error) {
+	normalized := make(map[string]string, len(slots))
+	for slotArg, valueArg := range slots {
+		slot, err := evm.ParseSlot(slotArg)
+		if err != nil {
+			return nil, err
+		}
+		value, err := evm.ParseSlot(valueArg)
+		if err != nil {
+			return nil, fmt.Errorf("invalid storage value: %s", valueArg)
+		}
+		normalized[evm.FormatSlot(slot)] = evm.FormatSlot(value)
+	}
+	return normalized, nil
+}
+
+// describeCallFailure turns a failed eth_call into a decoded revert, or returns the error
+// unchanged when the call failed for reasons other than a revert (e.g. a network error)
+func describeCallFailure(client etherscan.Explorer, chainID, to string, err error) (*abi.Revert, error) {
+	var rpcErr *rpc.Error
+	if !errors.As(err, &rpcErr) {
+		return nil, err
+	}
+
+	var customErrors *abi.ABI
+	if to != "" {
+		customErrors = contractABIs.lookup(client, chainID, to)
+	}
+
+	if revertData, ok := rpc.RevertData(err); ok {
+		if data, decodeErr := hex.DecodeString(trimHexPrefix(revertData)); decodeErr == nil {
+			return abi.DecodeRevert(data, customErrors), nil
+		}
+	}
+
+	// Malformed requests are not execution failures
+	if rpcErr.Code == rpcMethodNotFound || rpcErr.Code == rpcInvalidParams {
+		return nil, err
+	}
+
+	// Without revert data the node still reports the failure (revert, out of gas, insufficient funds) in the message
+	revert := abi.DecodeRevert(nil, nil)
+	revert.Reason = rpcErr.Message
+	return revert, nil
+}
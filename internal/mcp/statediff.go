@@ -0,0 +1,335 @@
+package mcp
+
+import (
+	"encoding/json"
+	"fmt"
+	"log"
+	"math/big"
+	"sort"
+	"strings"
+
+	"github.com/huahuayu/etherscan-mcp-server/internal/chains"
+	"github.com/huahuayu/etherscan-mcp-server/internal/etherscan"
+	"github.com/huahuayu/etherscan-mcp-server/internal/rpc"
+	"github.com/huahuayu/etherscan-mcp-server/internal/units"
+)
+
+// accountDiff summarizes how a transaction changed one account
+type accountDiff struct {
+	Balance *valueChange    `json:"balance,omitempty"`
+	Nonce   *valueChange    `json:"nonce,omitempty"`
+	Code    string          `json:"code,omitempty"` // created, changed or removed
+	Storage []storageChange `json:"storage,omitempty"`
+	Tokens  []tokenChange   `json:"tokens,omitempty"`
+}
+
+// valueChange is a numeric value before and after a transaction
+type valueChange struct {
+	Before          string `json:"before"`
+	After           string `json:"after"`
+	Change          string `json:"change,omitempty"`
+	ChangeFormatted string `json:"changeFormatted,omitempty"`
+}
+
+// storageChange is a storage slot write
+type storageChange struct {
+	Slot   string `json:"slot"`
+	Before string `json:"before"`
+	After  string `json:"after"`
+}
+
+// tokenChange is an ERC20 balance change derived from Transfer logs
+type tokenChange struct {
+	Token           string `json:"token"`
+	Change          string `json:"change"`
+	ChangeFormatted string `json:"changeFormatted,omitempty"`
+	Decimals        *int   `json:"decimals,omitempty"`
+}
+
+// prestateAccount is an account in the output of geth's prestateTracer
+type prestateAccount struct {
+	Balance string            `json:"balance"`
+	Nonce   *uint64           `json:"nonce"`
+	Code    string            `json:"code"`
+	Storage map[string]string `json:"storage"`
+}
+
+// prestateDiff is the output of prestateTracer in diffMode
+type prestateDiff struct {
+	Pre  map[string]prestateAccount `json:"pre"`
+	Post map[string]prestateAccount `json:"post"`
+}
+
+// parityStateDiff is the stateDiff section of trace_replayTransaction
+type parityStateDiff struct {
+	StateDiff map[string]struct {
+		Balance json.RawMessage            `json:"balance"`
+		Nonce   json.RawMessage            `json:"nonce"`
+		Code    json.RawMessage            `json:"code"`
+		Storage map[string]json.RawMessage `json:"storage"`
+	} `json:"stateDiff"`
+}
+
+// zeroWord is an empty storage slot
+const zeroWord = "0x0000000000000000000000000000000000000000000000000000000000000000"
+
+// traceStateDiff gets the state changes of a transaction, preferring prestateTracer in diffMode and
+// falling back to trace_replayTransaction. Returns the diffs keyed by lowercase address and the method used.
+func traceStateDiff(rpcClient *rpc.Client, chainID, txHash string) (map[string]*accountDiff, string, error) {
+	decimals := chains.Native(chainID).Decimals
+	options := map[string]interface{}{
+		"tracer":       "prestateTracer",
+		"tracerConfig": map[string]interface{}{"diffMode": true},
+	}
+	result, err := rpcClient.DebugTraceTransaction(chainID, txHash, options)
+	if err == nil {
+		var diff prestateDiff
+		if err := json.Unmarshal(result, &diff); err != nil {
+			return nil, "", fmt.Errorf("failed to parse prestate trace: %w", err)
+		}
+		return fromPrestateDiff(diff, decimals), "prestateTracer", nil
+	}
+	log.Printf("debug_traceTransaction unavailable for chain %s (%v), trying trace_replayTransaction", chainID, err)
+
+	result, replayErr := rpcClient.TraceReplayTransaction(chainID, txHash, []string{"stateDiff"})
+	if replayErr != nil {
+		return nil, "", fmt.Errorf("the RPC endpoint for chain %s supports neither debug_traceTransaction (%v) nor trace_replayTransaction (%v)", chainID, err, replayErr)
+	}
+
+	var replay parityStateDiff
+	if err := json.Unmarshal(result, &replay); err != nil {
+		return nil, "", fmt.Errorf("failed to parse state diff: %w", err)
+	}
+	diffs, err := fromParityStateDiff(replay, decimals)
+	if err != nil {
+		return nil, "", err
+	}
+	return diffs, "trace_replayTransaction", nil
+}
+
+// fromPrestateDiff converts prestateTracer diffMode output. In diffMode, post only holds modified
+// fields, and an account present in pre but missing from post was removed.
+func fromPrestateDiff(diff prestateDiff, decimals int) map[string]*accountDiff {
+	diffs := make(map[string]*accountDiff)
+
+	addresses := make(map[string]bool)
+	for address := range diff.Pre {
+		addresses[address] = true
+	}
+	for address := range diff.Post {
+		addresses[address] = true
+	}
+
+	for address := range addresses {
+		pre, existed := diff.Pre[address]
+		post, exists := diff.Post[address]
+		account := &accountDiff{}
+
+		if post.Balance != "" || (existed && !exists) {
+			before, after := pre.Balance, post.Balance
+			if before == "" {
+				before = "0x0"
+			}
+			if after == "" {
+				after = "0x0"
+			}
+			account.Balance = newValueChange(before, after, decimals)
+		}
+
+		if post.Nonce != nil {
+			var before uint64
+			if pre.Nonce != nil {
+				before = *pre.Nonce
+			}
+			account.Nonce = newValueChange(fmt.Sprint(before), fmt.Sprint(*post.Nonce), 0)
+		}
+
+		switch {
+		case existed && !exists && pre.Code != "":
+			account.Code = "removed"
+		case post.Code != "" && pre.Code == "":
+			account.Code = "created"
+		case post.Code != "" && post.Code != pre.Code:
+			account.Code = "changed"
+		}
+
+		slots := make(map[string]bool)
+		for slot := range pre.Storage {
+			slots[slot] = true
+		}
+		for slot := range post.Storage {
+			slots[slot] = true
+		}
+		for slot := range slots {
+			before, ok := pre.Storage[slot]
+			if !ok {
+				before = zeroWord
+			}
+			after, ok := post.Storage[slot]
+			if !ok {
+				// Cleared slots are omitted from post
+				after = zeroWord
+			}
+			if before != after {
+				account.Storage = append(account.Storage, storageChange{Slot: slot, Before: before, After: after})
+			}
+		}
+
+		diffs[strings.ToLower(address)] = account
+	}
+	return diffs
+}
+
+// fromParityStateDiff converts trace_replayTransaction state diffs, where each field is "=" (unchanged),
+// {"+": value} (created), {"-": value} (removed) or {"*": {"from": a, "to": b}} (changed)
+func fromParityStateDiff(replay parityStateDiff, decimals int) (map[string]*accountDiff, error) {
+	diffs := make(map[string]*accountDiff)
+	for address, change := range replay.StateDiff {
+		account := &accountDiff{}
+
+		before, after, kind, err := parityChange(change.Balance)
+		if err != nil {
+			return nil, err
+		}
+		if kind != "" {
+			account.Balance = newValueChange(orZero(before), orZero(after), decimals)
+		}
+
+		before, after, kind, err = parityChange(change.Nonce)
+		if err != nil {
+			return nil, err
+		}
+		if kind != "" {
+			account.Nonce = newValueChange(orZero(before), orZero(after), 0)
+		}
+
+		_, _, kind, err = parityChange(change.Code)
+		if err != nil {
+			return nil, err
+		}
+		switch kind {
+		case "+":
+			account.Code = "created"
+		case "-":
+			account.Code = "removed"
+		case "*":
+			account.Code = "changed"
+		}
+
+		for slot, raw := range change.Storage {
+			before, after, kind, err := parityChange(raw)
+			if err != nil {
+				return nil, err
+			}
+			if kind == "" {
+				continue
+			}
+			if before == "" {
+				before = zeroWord
+			}
+			if after == "" {
+				after = zeroWord
+			}
+			account.Storage = append(account.Storage, storageChange{Slot: slot, Before: before, After: after})
+		}
+
+		diffs[strings.ToLower(address)] = account
+	}
+	return diffs, nil
+}
+
+// parityChange decodes a single trace_replayTransaction field diff; kind is "" when unchanged
+func parityChange(raw json.RawMessage) (before, after, kind string, err error) {
+	if len(raw) == 0 || string(raw) == `"="` {
+		return "", "", "", nil
+	}
+
+	var change map[string]json.RawMessage
+	if err := json.Unmarshal(raw, &change); err != nil {
+		return "", "", "", fmt.Errorf("failed to parse state diff entry: %w", err)
+	}
+
+	if value, ok := change["+"]; ok {
+		err = json.Unmarshal(value, &after)
+		return "", after, "+", err
+	}
+	if value, ok := change["-"]; ok {
+		err = json.Unmarshal(value, &before)
+		return before, "", "-", err
+	}
+	if value, ok := change["*"]; ok {
+		var fromTo struct {
+			From string `json:"from"`
+			To   string `json:"to"`
+		}
+		err = json.Unmarshal(value, &fromTo)
+		return fromTo.From, fromTo.To, "*", err
+	}
+	return "", "", "", nil
+}
+
+// newValueChange builds a value change from decimal or hex values, formatting the change with decimals
+func newValueChange(before, after string, decimals int) *valueChange {
+	beforeValue, okBefore := units.ParseBigInt(before)
+	afterValue, okAfter := units.ParseBigInt(after)
+	if !okBefore || !okAfter {
+		return &valueChange{Before: before, After: after}
+	}
+
+	change := new(big.Int).Sub(afterValue, beforeValue)
+	result := &valueChange{
+		Before: beforeValue.String(),
+		After:  afterValue.String(),
+		Change: change.String(),
+	}
+	if decimals > 0 {
+		result.ChangeFormatted = units.FormatUnits(change, decimals)
+	}
+	return result
+}
+
+// addTokenChanges adds ERC20 balance changes from Transfer logs to the account diffs
+func addTokenChanges(client etherscan.Explorer, rpcClient *rpc.Client, chainID string, diffs map[string]*accountDiff, logs []logEntry) {
+	decimals := make(map[string]*int)
+	for holder, tokens := range erc20BalanceChanges(tokenTransfers(logs)) {
+		account, ok := diffs[holder]
+		if !ok {
+			account = &accountDiff{}
+			diffs[holder] = account
+		}
+
+		for token, change := range tokens {
+			if change.Sign() == 0 {
+				continue
+			}
+			if _, ok := decimals[token]; !ok {
+				if value, err := tokenDecimals(client, rpcClient, chainID, token); err == nil {
+					decimals[token] = &value
+				} else {
+					decimals[token] = nil
+				}
+			}
+
+			tokenDiff := tokenChange{Token: token, Change: change.String(), Decimals: decimals[token]}
+			if decimals[token] != nil {
+				tokenDiff.ChangeFormatted = units.FormatUnits(change, *decimals[token])
+			}
+			account.Tokens = append(account.Tokens, tokenDiff)
+		}
+		sort.Slice(account.Tokens, func(i, j int) bool { return account.Tokens[i].Token < account.Tokens[j].Token })
+	}
+}
+
+// sortStorageChanges orders storage writes by slot for stable output
+func sortStorageChanges(diffs map[string]*accountDiff) {
+	for _, account := range diffs {
+		sort.Slice(account.Storage, func(i, j int) bool { return account.Storage[i].Slot < account.Storage[j].Slot })
+	}
+}
+
+func orZero(value string) string {
+	if value == "" {
+		return "0x0"
+	}
+	return value
+}
@@ -0,0 +1,159 @@
+package mcp
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+)
+
+const (
+	word1 = "0x0000000000000000000000000000000000000000000000000000000000000001"
+	word2 = "0x0000000000000000000000000000000000000000000000000000000000000002"
+	word3 = "0x0000000000000000000000000000000000000000000000000000000000000003"
+)
+
+// diffsJSON renders account diffs with storage writes in slot order
+func diffsJSON(t *testing.T, diffs map[string]*accountDiff) string {
+	t.Helper()
+	sortStorageChanges(diffs)
+	encoded, err := json.Marshal(diffs)
+	if err != nil {
+		t.Fatalf("failed to encode diffs: %v", err)
+	}
+	return string(encoded)
+}
+
+func TestFromPrestateDiff(t *testing.T) {
+	tests := []struct {
+		name string
+		diff string
+		want string
+	}{
+		{
+			name: "balance and nonce changes",
+			diff: `{"pre":{"0xAbC":{"balance":"0xde0b6b3a7640000","nonce":1}},"post":{"0xAbC":{"balance":"0x6f05b59d3b20000","nonce":2}}}`,
+			want: `{"0xabc":{
+				"balance":{"before":"1000000000000000000","after":"500000000000000000","change":"-500000000000000000","changeFormatted":"-0.5"},
+				"nonce":{"before":"1","after":"2","change":"1"}
+			}}`,
+		},
+		{
+			name: "fields omitted from post are unchanged and omitted slots are cleared",
+			diff: `{"pre":{"0xb":{"balance":"0x1","nonce":5,"code":"0x60","storage":{"0x01":"` + word1 + `","0x02":"` + word2 + `"}}},
+				"post":{"0xb":{"storage":{"0x01":"` + word3 + `"}}}}`,
+			want: `{"0xb":{"storage":[
+				{"slot":"0x01","before":"` + word1 + `","after":"` + word3 + `"},
+				{"slot":"0x02","before":"` + word2 + `","after":"` + zeroWord + `"}
+			]}}`,
+		},
+		{
+			name: "created contract",
+			diff: `{"pre":{},"post":{"0xc":{"nonce":1,"code":"0x6080","storage":{"0x00":"` + word1 + `"}}}}`,
+			want: `{"0xc":{
+				"nonce":{"before":"0","after":"1","change":"1"},
+				"code":"created",
+				"storage":[{"slot":"0x00","before":"` + zeroWord + `","after":"` + word1 + `"}]
+			}}`,
+		},
+		{
+			name: "changed code",
+			diff: `{"pre":{"0xe":{"code":"0x60"}},"post":{"0xe":{"code":"0x61"}}}`,
+			want: `{"0xe":{"code":"changed"}}`,
+		},
+		{
+			name: "removed account",
+			diff: `{"pre":{"0xd":{"balance":"0x5","nonce":1,"code":"0x60"}},"post":{}}`,
+			want: `{"0xd":{
+				"balance":{"before":"5","after":"0","change":"-5","changeFormatted":"-0.000000000000000005"},
+				"code":"removed"
+			}}`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var diff prestateDiff
+			if err := json.Unmarshal([]byte(tt.diff), &diff); err != nil {
+				t.Fatalf("invalid test diff: %v", err)
+			}
+			got := diffsJSON(t, fromPrestateDiff(diff, 18))
+			if !jsonEqual(t, got, tt.want) {
+				t.Errorf("got %s, want %s", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestFromParityStateDiff(t *testing.T) {
+	tests := []struct {
+		name    string
+		replay  string
+		want    string
+		wantErr string
+	}{
+		{
+			name: "changed balance and nonce",
+			replay: `{"stateDiff":{"0xAbC":{"balance":{"*":{"from":"0xde0b6b3a7640000","to":"0x6f05b59d3b20000"}},
+				"nonce":{"*":{"from":"0x1","to":"0x2"}},"code":"=","storage":{}}}}`,
+			want: `{"0xabc":{
+				"balance":{"before":"1000000000000000000","after":"500000000000000000","change":"-500000000000000000","changeFormatted":"-0.5"},
+				"nonce":{"before":"1","after":"2","change":"1"}
+			}}`,
+		},
+		{
+			name: "storage writes skip unchanged slots",
+			replay: `{"stateDiff":{"0xb":{"balance":"=","nonce":"=","code":"=",
+				"storage":{"0x01":{"*":{"from":"` + word1 + `","to":"` + word3 + `"}},"0x02":"="}}}}`,
+			want: `{"0xb":{"storage":[{"slot":"0x01","before":"` + word1 + `","after":"` + word3 + `"}]}}`,
+		},
+		{
+			name: "created account",
+			replay: `{"stateDiff":{"0xc":{"balance":{"+":"0x0"},"nonce":{"+":"0x1"},"code":{"+":"0x6080"},
+				"storage":{"0x00":{"+":"` + word1 + `"}}}}}`,
+			want: `{"0xc":{
+				"balance":{"before":"0","after":"0","change":"0","changeFormatted":"0"},
+				"nonce":{"before":"0","after":"1","change":"1"},
+				"code":"created",
+				"storage":[{"slot":"0x00","before":"` + zeroWord + `","after":"` + word1 + `"}]
+			}}`,
+		},
+		{
+			name: "removed account",
+			replay: `{"stateDiff":{"0xd":{"balance":{"-":"0x5"},"nonce":{"-":"0x1"},"code":{"-":"0x60"},
+				"storage":{"0x01":{"-":"` + word2 + `"}}}}}`,
+			want: `{"0xd":{
+				"balance":{"before":"5","after":"0","change":"-5","changeFormatted":"-0.000000000000000005"},
+				"nonce":{"before":"1","after":"0","change":"-1"},
+				"code":"removed",
+				"storage":[{"slot":"0x01","before":"` + word2 + `","after":"` + zeroWord + `"}]
+			}}`,
+		},
+		{
+			name:    "malformed field",
+			replay:  `{"stateDiff":{"0xa":{"balance":"changed"}}}`,
+			wantErr: "failed to parse state diff entry",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var replay parityStateDiff
+			if err := json.Unmarshal([]byte(tt.replay), &replay); err != nil {
+				t.Fatalf("invalid test state diff: %v", err)
+			}
+			diffs, err := fromParityStateDiff(replay, 18)
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("fromParityStateDiff() error = %v, want %q", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("fromParityStateDiff() error = %v", err)
+			}
+			if got := diffsJSON(t, diffs); !jsonEqual(t, got, tt.want) {
+				t.Errorf("got %s, want %s", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,106 @@
+package mcp
+
+import (
+	"encoding/json"
+	"fmt"
+	"math/big"
+
+	"github.com/huahuayu/etherscan-mcp-server/internal/etherscan"
+	"github.com/huahuayu/etherscan-mcp-server/internal/evm"
+	"github.com/huahuayu/etherscan-mcp-server/internal/rpc"
+	"github.com/huahuayu/etherscan-mcp-server/internal/units"
+)
+
+// slotPathStep is one step of a storage layout path used to derive a slot from a base slot
+type slotPathStep struct {
+	// Type is one of "mapping", "dynamicArray", "fixedArray" or "field"
+	Type string `json:"type"`
+	// KeyType and Key describe a mapping lookup (e.g. "address", "0x...")
+	KeyType string `json:"keyType,omitempty"`
+	Key     string `json:"key,omitempty"`
+	// Index and ElementSlots describe an array element; the index is decimal unless 0x-prefixed,
+	// and ElementSlots defaults to 1
+	Index        string `json:"index,omitempty"`
+	ElementSlots int64  `json:"elementSlots,omitempty"`
+	// Offset is the slot offset of a struct member
+	Offset int64 `json:"offset,omitempty"`
+}
+
+// getCode gets the bytecode at an address from the chain's backend, returning the backend that answered
+func getCode(client etherscan.Explorer, rpcClient *rpc.Client, chainID, address, tag string) (string, string, error) {
+	return fetch(chainID,
+		func() (string, error) { return client.GetCode(chainID, address, tag) },
+		func() (string, error) { return rpcClient.GetCode(chainID, address, tag) })
+}
+
+// getStorageAt reads a storage slot from the chain's backend, returning the backend that answered
+func getStorageAt(client etherscan.Explorer, rpcClient *rpc.Client, chainID, address string, slot *big.Int, tag string) (string, string, error) {
+	position := evm.FormatSlot(slot)
+	return fetch(chainID,
+		func() (string, error) { return client.GetStorageAt(chainID, address, position, tag) },
+		func() (string, error) { return rpcClient.GetStorageAt(chainID, address, position, tag) })
+}
+
+// resolveSlotPath applies a JSON-encoded layout path to a base slot
+func resolveSlotPath(baseSlot *big.Int, pathJSON string) (*big.Int, error) {
+	var steps []slotPathStep
+	if err := json.Unmarshal([]byte(pathJSON), &steps); err != nil {
+		return nil, fmt.Errorf("path must be a JSON array of steps: %w", err)
+	}
+
+	slot := baseSlot
+	for i, step := range steps {
+		elementSlots := step.ElementSlots
+		if elementSlots <= 0 {
+			elementSlots = 1
+		}
+
+		switch step.Type {
+		case "mapping":
+			next, err := evm.MappingSlot(slot, step.KeyType, step.Key)
+			if err != nil {
+				return nil, fmt.Errorf("step %d: %w", i, err)
+			}
+			slot = next
+		case "dynamicArray", "fixedArray":
+			index, ok := units.ParseBigInt(step.Index)
+			if !ok || index.Sign() < 0 {
+				return nil, fmt.Errorf("step %d: invalid array index %q", i, step.Index)
+			}
+			if step.Type == "dynamicArray" {
+				slot = evm.DynamicArraySlot(slot, index, elementSlots)
+			} else {
+				slot = evm.AddSlot(slot, new(big.Int).Mul(index, big.NewInt(elementSlots)))
+			}
+		case "field":
+			slot = evm.AddSlot(slot, big.NewInt(step.Offset))
+		default:
+			return nil, fmt.Errorf("step %d: unknown step type %q (expected mapping, dynamicArray, fixedArray or field)", i, step.Type)
+		}
+	}
+
+	return slot, nil
+}
+
+// describeStorageValue returns a storage word along with its common interpretations
+func describeStorageValue(slot *big.Int, value string) map[string]interface{} {
+	response := map[string]interface{}{
+		"slot":  evm.FormatSlot(slot),
+		"value": value,
+	}
+	if number, ok := new(big.Int).SetString(trimHexPrefix(value), 16); ok {
+		response["uint256"] = number.String()
+	}
+	if address, ok := evm.AddressFromWord(value); ok {
+		response["address"] = address
+	}
+	return response
+}
+
+// trimHexPrefix removes a leading 0x from a hex string
+func trimHexPrefix(value string) string {
+	if len(value) >= 2 && value[0] == '0' && (value[1] == 'x' || value[1] == 'X') {
+		return value[2:]
+	}
+	return value
+}
@@ -0,0 +1,65 @@
+package mcp
+
+import (
+	"math/big"
+	"strings"
+	"testing"
+
+	"github.com/huahuayu/etherscan-mcp-server/internal/evm"
+)
+
+func TestResolveSlotPath(t *testing.T) {
+	tests := []struct {
+		name    string
+		base    int64
+		path    string
+		want    string
+		wantErr string
+	}{
+		{
+			name: "fixed array index is decimal despite a leading zero",
+			base: 4,
+			path: `[{"type":"fixedArray","index":"010"}]`,
+			want: evm.FormatSlot(big.NewInt(14)),
+		},
+		{
+			name: "hex fixed array index with multi-slot elements",
+			base: 4,
+			path: `[{"type":"fixedArray","index":"0x10","elementSlots":2}]`,
+			want: evm.FormatSlot(big.NewInt(36)),
+		},
+		{
+			name: "struct member of a mapping value",
+			path: `[{"type":"mapping","keyType":"address","key":"0xd8dA6BF26964aF9D7eEd9e03E53415D37aA96045"},{"type":"field","offset":1}]`,
+			want: "0xfca351f4d96129454cfc8ef7930b638ac71fea35eb69ee3b8d959496beb04a34",
+		},
+		{
+			name: "dynamic array element",
+			base: 2,
+			path: `[{"type":"dynamicArray","index":"3","elementSlots":2}]`,
+			want: "0x405787fa12a823e0f2b7631cc41b3ba8828b3321ca811111fa75cd3aa3bb5ad4",
+		},
+		{name: "negative index", path: `[{"type":"fixedArray","index":"-1"}]`, wantErr: "invalid array index"},
+		{name: "non-numeric index", path: `[{"type":"dynamicArray","index":"1e3"}]`, wantErr: "invalid array index"},
+		{name: "unknown step", path: `[{"type":"slice"}]`, wantErr: "unknown step type"},
+		{name: "not an array", path: `{"type":"field"}`, wantErr: "path must be a JSON array"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			slot, err := resolveSlotPath(big.NewInt(tt.base), tt.path)
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("resolveSlotPath() error = %v, want %q", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("resolveSlotPath() error = %v", err)
+			}
+			if got := evm.FormatSlot(slot); got != tt.want {
+				t.Errorf("got %s, want %s", got, tt.want)
+			}
+		})
+	}
+}
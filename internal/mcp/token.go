@@ -0,0 +1,178 @@
+package mcp
+
+import (
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"math"
+	"math/big"
+	"sort"
+	"strings"
+
+	"github.com/huahuayu/etherscan-mcp-server/internal/abi"
+	"github.com/huahuayu/etherscan-mcp-server/internal/etherscan"
+	"github.com/huahuayu/etherscan-mcp-server/internal/rpc"
+	"github.com/huahuayu/etherscan-mcp-server/internal/units"
+)
+
+// ERC20 read-only function selectors
+const (
+	selectorDecimals    = "0x313ce567" // decimals()
+	selectorTotalSupply = "0x18160ddd" // totalSupply()
+	selectorBalanceOf   = "0x70a08231" // balanceOf(address)
+	selectorSymbol      = "0x95d89b41" // symbol()
+)
+
+// burnAddresses hold tokens that are permanently out of circulation
+var burnAddresses = []string{
+	"0x0000000000000000000000000000000000000000",
+	"0x000000000000000000000000000000000000dEaD",
+}
+
+// callContract performs an eth_call on the chain's backend, returning the hex result
+func callContract(client etherscan.Explorer, rpcClient *rpc.Client, chainID, to, data string) (string, error) {
+	result, _, err := fetch(chainID,
+		func() (json.RawMessage, error) { return client.ExecuteContractMethod(chainID, to, data, "") },
+		func() (json.RawMessage, error) { return rpcClient.EthCall(chainID, to, data) })
+	if err != nil {
+		return "", err
+	}
+
+	var hexValue string
+	if err := json.Unmarshal(result, &hexValue); err != nil {
+		return "", fmt.Errorf("failed to parse call result: %w", err)
+	}
+
+	return hexValue, nil
+}
+
+// callContractAt performs an eth_call against the state at a block. Historical calls need an RPC
+// endpoint because the Etherscan proxy only executes calls against the latest state.
+func callContractAt(client etherscan.Explorer, rpcClient *rpc.Client, chainID, to, data, blockNumber string) (string, error) {
+	if blockNumber == "" || blockNumber == "latest" {
+		return callContract(client, rpcClient, chainID, to, data)
+	}
+
+	if !rpc.IsRPCFallbackChain(chainID) {
+		return "", fmt.Errorf("calls at block %s require an RPC endpoint for chain %s (configure one via RPC_URLS)", blockNumber, chainID)
+	}
+
+	result, err := rpcClient.EthCallAt(chainID, to, data, blockNumber)
+	if err != nil {
+		return "", err
+	}
+
+	var hexValue string
+	if err := json.Unmarshal(result, &hexValue); err != nil {
+		return "", fmt.Errorf("failed to parse call result: %w", err)
+	}
+
+	return hexValue, nil
+}
+
+// tokenDecimals reads decimals() of an ERC20 token
+func tokenDecimals(client etherscan.Explorer, rpcClient *rpc.Client, chainID, contractAddress string) (int, error) {
+	hexValue, err := callContract(client, rpcClient, chainID, contractAddress, selectorDecimals)
+	if err != nil {
+		return 0, err
+	}
+
+	decimals, ok := units.ParseBigInt(hexValue)
+	if !ok || !decimals.IsInt64() || decimals.Int64() > 255 {
+		return 0, fmt.Errorf("invalid decimals returned by %s: %s", contractAddress, hexValue)
+	}
+
+	return int(decimals.Int64()), nil
+}
+
+// tokenSymbol reads symbol() of a token, accepting both string and legacy bytes32 return values
+func tokenSymbol(client etherscan.Explorer, rpcClient *rpc.Client, chainID, contractAddress string) (string, error) {
+	hexValue, err := callContract(client, rpcClient, chainID, contractAddress, selectorSymbol)
+	if err != nil {
+		return "", err
+	}
+
+	data, err := hex.DecodeString(trimHexPrefix(hexValue))
+	if err != nil {
+		return "", fmt.Errorf("invalid symbol returned by %s: %s", contractAddress, hexValue)
+	}
+	if decoded, err := abi.DecodeArguments([]abi.Argument{{Type: "string"}}, data); err == nil {
+		return decoded[0].Value.(string), nil
+	}
+	if len(data) == 32 {
+		return strings.TrimRight(string(data), "\x00"), nil
+	}
+
+	return "", fmt.Errorf("invalid symbol returned by %s: %s", contractAddress, hexValue)
+}
+
+// tokenTotalSupply reads totalSupply() of an ERC20 token
+func tokenTotalSupply(client etherscan.Explorer, rpcClient *rpc.Client, chainID, contractAddress string) (*big.Int, error) {
+	return tokenTotalSupplyAt(client, rpcClient, chainID, contractAddress, "latest")
+}
+
+// tokenTotalSupplyAt reads totalSupply() of an ERC20 token at a block
+func tokenTotalSupplyAt(client etherscan.Explorer, rpcClient *rpc.Client, chainID, contractAddress, blockNumber string) (*big.Int, error) {
+	hexValue, err := callContractAt(client, rpcClient, chainID, contractAddress, selectorTotalSupply, blockNumber)
+	if err != nil {
+		return nil, err
+	}
+
+	supply, ok := units.ParseBigInt(hexValue)
+	if !ok {
+		return nil, fmt.Errorf("invalid totalSupply returned by %s: %s", contractAddress, hexValue)
+	}
+
+	return supply, nil
+}
+
+// tokenBalanceAt reads balanceOf(holder) of an ERC20 token at a block
+func tokenBalanceAt(client etherscan.Explorer, rpcClient *rpc.Client, chainID, contractAddress, holder, blockNumber string) (*big.Int, error) {
+	if !addressPattern.MatchString(holder) {
+		return nil, fmt.Errorf("invalid address: %s", holder)
+	}
+	address, err := hex.DecodeString(holder[2:])
+	if err != nil {
+		return nil, fmt.Errorf("invalid address: %s", holder)
+	}
+	word := append(make([]byte, 32-len(address)), address...)
+	data := selectorBalanceOf + hex.EncodeToString(word)
+
+	hexValue, err := callContractAt(client, rpcClient, chainID, contractAddress, data, blockNumber)
+	if err != nil {
+		return nil, err
+	}
+
+	balance, ok := units.ParseBigInt(hexValue)
+	if !ok {
+		return nil, fmt.Errorf("invalid balance returned by %s: %s", contractAddress, hexValue)
+	}
+
+	return balance, nil
+}
+
+// giniCoefficient measures how unequally balances are distributed (0 = equal, 1 = one holder has everything)
+func giniCoefficient(balances []*big.Int) float64 {
+	n := len(balances)
+	if n == 0 {
+		return 0
+	}
+
+	values := make([]float64, n)
+	for i, balance := range balances {
+		values[i], _ = new(big.Float).SetInt(balance).Float64()
+	}
+	sort.Float64s(values)
+
+	var sum, weighted float64
+	for i, v := range values {
+		sum += v
+		weighted += float64(i+1) * v
+	}
+	if sum == 0 {
+		return 0
+	}
+
+	gini := 2*weighted/(float64(n)*sum) - float64(n+1)/float64(n)
+	return math.Round(gini*10000) / 10000
+}
@@ -2,7 +2,10 @@ package mcp
 
 import (
 	"context"
+	"fmt"
+	"strings"
 
+	"github.com/huahuayu/etherscan-mcp-server/internal/chains"
 	"github.com/huahuayu/etherscan-mcp-server/internal/etherscan"
 	"github.com/huahuayu/etherscan-mcp-server/internal/rpc"
 	"github.com/mark3labs/mcp-go/mcp"
@@ -10,7 +13,10 @@ import (
 )
 
 // RegisterTools registers all the Etherscan API tools with the MCP server
-func RegisterTools(s *server.MCPServer, client *etherscan.Client, rpcClient *rpc.Client) {
+func RegisterTools(s *server.MCPServer, client etherscan.Explorer, rpcClient *rpc.Client) {
+	explorerCapabilities = client
+	tools := newToolRegistrar(s, client, rpcClient)
+
 	// 1. Get Account Balance
 	accountBalanceTool := mcp.NewTool("getAccountBalance",
 		mcp.WithDescription("Get the balance of an account on a specific blockchain"),
@@ -23,7 +29,7 @@ func RegisterTools(s *server.MCPServer, client *etherscan.Client, rpcClient *rpc
 			mcp.Description("The account address"),
 		),
 	)
-	s.AddTool(accountBalanceTool, func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	tools.add(accountBalanceTool, func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
 		return handleGetAccountBalance(ctx, request, client, rpcClient)
 	})
 
@@ -38,9 +44,31 @@ func RegisterTools(s *server.MCPServer, client *etherscan.Client, rpcClient *rpc
 			mcp.Required(),
 			mcp.Description("The block number (or 'latest')"),
 		),
+		mcp.WithBoolean("fullTransactions",
+			mcp.Description("Return full transaction objects instead of transaction hashes (default: false)"),
+		),
+	)
+	tools.add(blockByNumberTool, func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		return handleGetBlockByNumber(ctx, request, client, rpcClient)
+	})
+
+	// 2a. Get Block By Hash
+	blockByHashTool := mcp.NewTool("getBlockByHash",
+		mcp.WithDescription("Get block information by block hash (requires an RPC endpoint for the chain)"),
+		mcp.WithString("chainID",
+			mcp.Required(),
+			mcp.Description("The chain ID (e.g., 1 for Ethereum)"),
+		),
+		mcp.WithString("blockHash",
+			mcp.Required(),
+			mcp.Description("The block hash"),
+		),
+		mcp.WithBoolean("fullTransactions",
+			mcp.Description("Return full transaction objects instead of transaction hashes (default: false)"),
+		),
 	)
-	s.AddTool(blockByNumberTool, func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
-		return handleGetBlockByNumber(ctx, request, client)
+	tools.add(blockByHashTool, func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		return handleGetBlockByHash(ctx, request, rpcClient)
 	})
 
 	// 3. Get Block Rewards
@@ -55,10 +83,42 @@ func RegisterTools(s *server.MCPServer, client *etherscan.Client, rpcClient *rpc
 			mcp.Description("The block number"),
 		),
 	)
-	s.AddTool(blockRewardsTool, func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	tools.add(blockRewardsTool, func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
 		return handleGetBlockRewards(ctx, request, client)
 	})
 
+	// 3a. Get Block Countdown
+	blockCountdownTool := mcp.NewTool("getBlockCountdown",
+		mcp.WithDescription("Get the estimated time remaining until a future block is mined"),
+		mcp.WithString("chainID",
+			mcp.Required(),
+			mcp.Description("The chain ID (e.g., 1 for Ethereum)"),
+		),
+		mcp.WithString("blockNumber",
+			mcp.Required(),
+			mcp.Description("The future block number"),
+		),
+	)
+	tools.add(blockCountdownTool, func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		return handleGetBlockCountdown(ctx, request, client, rpcClient)
+	})
+
+	// 3b. Estimate Block By Timestamp
+	estimateBlockByTimestampTool := mcp.NewTool("estimateBlockByTimestamp",
+		mcp.WithDescription("Get the block number at a past timestamp, or estimate the block number expected at a future timestamp from the recent average block time"),
+		mcp.WithString("chainID",
+			mcp.Required(),
+			mcp.Description("The chain ID (e.g., 1 for Ethereum)"),
+		),
+		mcp.WithString("timestamp",
+			mcp.Required(),
+			mcp.Description("Unix timestamp in seconds or RFC 3339 date-time (e.g. 2025-06-01T00:00:00Z)"),
+		),
+	)
+	tools.add(estimateBlockByTimestampTool, func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		return handleEstimateBlockByTimestamp(ctx, request, client, rpcClient)
+	})
+
 	// 4. Get Contract ABI
 	contractABITool := mcp.NewTool("getContractABI",
 		mcp.WithDescription("Get the ABI for a verified contract"),
@@ -70,14 +130,33 @@ func RegisterTools(s *server.MCPServer, client *etherscan.Client, rpcClient *rpc
 			mcp.Required(),
 			mcp.Description("The contract address"),
 		),
+		mcp.WithBoolean("includeImplementation",
+			mcp.Description("If the contract is a proxy, return the proxy ABI merged with its implementation ABI, or whichever of them is verified (default: false)"),
+		),
 	)
-	s.AddTool(contractABITool, func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
-		return handleGetContractABI(ctx, request, client)
+	tools.add(contractABITool, func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		return handleGetContractABI(ctx, request, client, rpcClient)
+	})
+
+	// 4a. Resolve Proxy
+	resolveProxyTool := mcp.NewTool("resolveProxy",
+		mcp.WithDescription("Detect whether a contract is a proxy (EIP-1967, transparent, UUPS, beacon, EIP-1822, EIP-1167 minimal proxy, Gnosis Safe) and resolve its implementation address"),
+		mcp.WithString("chainID",
+			mcp.Required(),
+			mcp.Description("The chain ID (e.g., 1 for Ethereum)"),
+		),
+		mcp.WithString("contractAddress",
+			mcp.Required(),
+			mcp.Description("The contract address"),
+		),
+	)
+	tools.add(resolveProxyTool, func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		return handleResolveProxy(ctx, request, client, rpcClient)
 	})
 
 	// 5. Get Contract Source Code
 	contractSourceCodeTool := mcp.NewTool("getContractSourceCode",
-		mcp.WithDescription("Get the source code of a verified contract"),
+		mcp.WithDescription("Get the source code of a verified contract as a file tree with compiler settings, remappings, libraries and constructor arguments. For large multi-file contracts, list the files first and then fetch them one at a time"),
 		mcp.WithString("chainID",
 			mcp.Required(),
 			mcp.Description("The chain ID (e.g., 1 for Ethereum)"),
@@ -86,11 +165,79 @@ func RegisterTools(s *server.MCPServer, client *etherscan.Client, rpcClient *rpc
 			mcp.Required(),
 			mcp.Description("The contract address"),
 		),
+		mcp.WithString("view",
+			mcp.Description("'full' for metadata and all files (default), 'list' for metadata and file paths/sizes only, 'file' for a single file, 'raw' for the unprocessed Etherscan response"),
+			mcp.Enum("full", "list", "file", "raw"),
+		),
+		mcp.WithString("filePath",
+			mcp.Description("The file to return when view is 'file' (full path or unambiguous suffix, e.g. 'ERC20.sol')"),
+		),
 	)
-	s.AddTool(contractSourceCodeTool, func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	tools.add(contractSourceCodeTool, func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
 		return handleGetContractSourceCode(ctx, request, client)
 	})
 
+	// 5a. Get Contract Code
+	contractCodeTool := mcp.NewTool("getContractCode",
+		mcp.WithDescription("Get the deployed bytecode at an address"),
+		mcp.WithString("chainID",
+			mcp.Required(),
+			mcp.Description("The chain ID (e.g., 1 for Ethereum)"),
+		),
+		mcp.WithString("address",
+			mcp.Required(),
+			mcp.Description("The contract address"),
+		),
+		mcp.WithString("tag",
+			mcp.Description("The block number tag (default: 'latest')"),
+		),
+	)
+	tools.add(contractCodeTool, func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		return handleGetContractCode(ctx, request, client, rpcClient)
+	})
+
+	// 5b. Get Storage At
+	storageAtTool := mcp.NewTool("getStorageAt",
+		mcp.WithDescription("Read a contract storage slot, optionally derived from a base slot through mapping keys, array indexes and struct offsets"),
+		mcp.WithString("chainID",
+			mcp.Required(),
+			mcp.Description("The chain ID (e.g., 1 for Ethereum)"),
+		),
+		mcp.WithString("address",
+			mcp.Required(),
+			mcp.Description("The contract address"),
+		),
+		mcp.WithString("slot",
+			mcp.Required(),
+			mcp.Description("The slot as a decimal or hex number, or a well-known slot: eip1967.implementation, eip1967.admin, eip1967.beacon, eip1822.proxiable, zeppelinos.implementation"),
+		),
+		mcp.WithString("path",
+			mcp.Description(slotPathDescription),
+		),
+		mcp.WithString("tag",
+			mcp.Description("The block number tag (default: 'latest')"),
+		),
+	)
+	tools.add(storageAtTool, func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		return handleGetStorageAt(ctx, request, client, rpcClient)
+	})
+
+	// 5c. Compute Storage Slot
+	computeStorageSlotTool := mcp.NewTool("computeStorageSlot",
+		mcp.WithDescription("Compute the storage slot of a mapping entry, array element or struct member from its Solidity layout, without reading it"),
+		mcp.WithString("baseSlot",
+			mcp.Required(),
+			mcp.Description("The slot of the top-level state variable (decimal or hex)"),
+		),
+		mcp.WithString("path",
+			mcp.Required(),
+			mcp.Description(slotPathDescription),
+		),
+	)
+	tools.add(computeStorageSlotTool, func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		return handleComputeStorageSlot(ctx, request)
+	})
+
 	// 6. Execute Contract Method
 	executeContractMethodTool := mcp.NewTool("executeContractMethod",
 		mcp.WithDescription("Execute a read contract function"),
@@ -110,7 +257,7 @@ func RegisterTools(s *server.MCPServer, client *etherscan.Client, rpcClient *rpc
 			mcp.Description("Comma-separated parameter values for the method"),
 		),
 	)
-	s.AddTool(executeContractMethodTool, func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	tools.add(executeContractMethodTool, func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
 		return handleExecuteContractMethod(ctx, request, client, rpcClient)
 	})
 
@@ -122,8 +269,81 @@ func RegisterTools(s *server.MCPServer, client *etherscan.Client, rpcClient *rpc
 			mcp.Description("The chain ID (e.g., 1 for Ethereum)"),
 		),
 	)
-	s.AddTool(gasOracleTool, func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
-		return handleGetGasOracle(ctx, request, client)
+	tools.add(gasOracleTool, func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		return handleGetGasOracle(ctx, request, client, rpcClient)
+	})
+
+	// 7a. Get Gas Confirmation Time Estimate
+	gasEstimateTool := mcp.NewTool("getGasEstimate",
+		mcp.WithDescription("Get the estimated confirmation time in seconds for a transaction paying a given gas price"),
+		mcp.WithString("chainID",
+			mcp.Required(),
+			mcp.Description("The chain ID (e.g., 1 for Ethereum)"),
+		),
+		mcp.WithString("gasPrice",
+			mcp.Required(),
+			mcp.Description("The gas price in wei"),
+		),
+	)
+	tools.add(gasEstimateTool, func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		return handleGetGasEstimate(ctx, request, client)
+	})
+
+	// 7b. Get Gas Price
+	gasPriceTool := mcp.NewTool("getGasPrice",
+		mcp.WithDescription("Get the current gas price in wei and gwei, plus the suggested EIP-1559 priority fee when an RPC endpoint is configured"),
+		mcp.WithString("chainID",
+			mcp.Required(),
+			mcp.Description("The chain ID (e.g., 1 for Ethereum)"),
+		),
+	)
+	tools.add(gasPriceTool, func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		return handleGetGasPrice(ctx, request, client, rpcClient)
+	})
+
+	// 7c. Get Fee History
+	feeHistoryTool := mcp.NewTool("getFeeHistory",
+		mcp.WithDescription("Get base fees, gas used ratios and priority fee percentiles for recent blocks (requires an RPC endpoint for the chain)"),
+		mcp.WithString("chainID",
+			mcp.Required(),
+			mcp.Description("The chain ID (e.g., 1 for Ethereum)"),
+		),
+		mcp.WithString("blockCount",
+			mcp.Description("Number of blocks to include, 1-1024 (default: 10)"),
+		),
+		mcp.WithString("newestBlock",
+			mcp.Description("The newest block of the range (default: 'latest')"),
+		),
+		mcp.WithString("rewardPercentiles",
+			mcp.Description("Comma-separated priority fee percentiles (default: '25,50,75')"),
+		),
+	)
+	tools.add(feeHistoryTool, func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		return handleGetFeeHistory(ctx, request, rpcClient)
+	})
+
+	// 7d. Estimate Gas
+	estimateGasTool := mcp.NewTool("estimateGas",
+		mcp.WithDescription("Estimate the gas used by a transaction and what it would cost at the current gas price"),
+		mcp.WithString("chainID",
+			mcp.Required(),
+			mcp.Description("The chain ID (e.g., 1 for Ethereum)"),
+		),
+		mcp.WithString("to",
+			mcp.Description("The recipient or contract address (omit for contract creation)"),
+		),
+		mcp.WithString("from",
+			mcp.Description("The sender address; estimates that set it use the RPC endpoint when the chain has one"),
+		),
+		mcp.WithString("data",
+			mcp.Description("The hex-encoded calldata"),
+		),
+		mcp.WithString("value",
+			mcp.Description("The value to send in wei (decimal or hex)"),
+		),
+	)
+	tools.add(estimateGasTool, func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		return handleEstimateGas(ctx, request, client, rpcClient)
 	})
 
 	// 8. Get Token Balance
@@ -142,7 +362,7 @@ func RegisterTools(s *server.MCPServer, client *etherscan.Client, rpcClient *rpc
 			mcp.Description("The account address"),
 		),
 	)
-	s.AddTool(tokenBalanceTool, func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	tools.add(tokenBalanceTool, func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
 		return handleGetTokenBalance(ctx, request, client, rpcClient)
 	})
 
@@ -158,7 +378,7 @@ func RegisterTools(s *server.MCPServer, client *etherscan.Client, rpcClient *rpc
 			mcp.Description("The token contract address"),
 		),
 	)
-	s.AddTool(tokenDetailsTool, func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	tools.add(tokenDetailsTool, func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
 		return handleGetTokenDetails(ctx, request, client, rpcClient)
 	})
 
@@ -173,10 +393,11 @@ func RegisterTools(s *server.MCPServer, client *etherscan.Client, rpcClient *rpc
 			mcp.Required(),
 			mcp.Description("The transaction hash"),
 		),
+		includeLabelsOption(),
 	)
-	s.AddTool(transactionByHashTool, func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	tools.add(transactionByHashTool, withLabels(client, func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
 		return handleGetTransactionByHash(ctx, request, client, rpcClient)
-	})
+	}))
 
 	// 10a. Get Transaction By Block Number And Index
 	transactionByBlockNumberAndIndexTool := mcp.NewTool("getTransactionByBlockNumberAndIndex",
@@ -193,10 +414,11 @@ func RegisterTools(s *server.MCPServer, client *etherscan.Client, rpcClient *rpc
 			mcp.Required(),
 			mcp.Description("The transaction index position"),
 		),
+		includeLabelsOption(),
 	)
-	s.AddTool(transactionByBlockNumberAndIndexTool, func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
-		return handleGetTransactionByBlockNumberAndIndex(ctx, request, client)
-	})
+	tools.add(transactionByBlockNumberAndIndexTool, withLabels(client, func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		return handleGetTransactionByBlockNumberAndIndex(ctx, request, client, rpcClient)
+	}))
 
 	// 10b. Get Transaction Count
 	transactionCountTool := mcp.NewTool("getTransactionCount",
@@ -213,7 +435,7 @@ func RegisterTools(s *server.MCPServer, client *etherscan.Client, rpcClient *rpc
 			mcp.Description("The block number tag (default: 'latest')"),
 		),
 	)
-	s.AddTool(transactionCountTool, func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	tools.add(transactionCountTool, func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
 		return handleGetTransactionCount(ctx, request, client, rpcClient)
 	})
 
@@ -228,10 +450,11 @@ func RegisterTools(s *server.MCPServer, client *etherscan.Client, rpcClient *rpc
 			mcp.Required(),
 			mcp.Description("The transaction hash"),
 		),
+		includeLabelsOption(),
 	)
-	s.AddTool(transactionReceiptTool, func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	tools.add(transactionReceiptTool, withLabels(client, func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
 		return handleGetTransactionReceipt(ctx, request, client, rpcClient)
-	})
+	}))
 
 	// 10c. Get Transaction Status
 	transactionStatusTool := mcp.NewTool("getTransactionStatus",
@@ -245,7 +468,7 @@ func RegisterTools(s *server.MCPServer, client *etherscan.Client, rpcClient *rpc
 			mcp.Description("The transaction hash"),
 		),
 	)
-	s.AddTool(transactionStatusTool, func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	tools.add(transactionStatusTool, func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
 		return handleGetTransactionStatus(ctx, request, client)
 	})
 
@@ -272,10 +495,11 @@ func RegisterTools(s *server.MCPServer, client *etherscan.Client, rpcClient *rpc
 		mcp.WithString("offset",
 			mcp.Description("Number of records to return"),
 		),
+		includeLabelsOption(),
 	)
-	s.AddTool(transactionsByAddressTool, func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	tools.add(transactionsByAddressTool, withLabels(client, func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
 		return handleGetTransactionsByAddress(ctx, request, client)
-	})
+	}))
 
 	// 12. Get Internal Transactions By Address
 	internalTransactionsByAddressTool := mcp.NewTool("getInternalTransactionsByAddress",
@@ -300,10 +524,11 @@ func RegisterTools(s *server.MCPServer, client *etherscan.Client, rpcClient *rpc
 		mcp.WithString("offset",
 			mcp.Description("Number of records to return"),
 		),
+		includeLabelsOption(),
 	)
-	s.AddTool(internalTransactionsByAddressTool, func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	tools.add(internalTransactionsByAddressTool, withLabels(client, func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
 		return handleGetInternalTransactionsByAddress(ctx, request, client)
-	})
+	}))
 
 	// 13. Get Token Transfers By Address
 	tokenTransfersByAddressTool := mcp.NewTool("getTokenTransfersByAddress",
@@ -331,10 +556,11 @@ func RegisterTools(s *server.MCPServer, client *etherscan.Client, rpcClient *rpc
 		mcp.WithString("offset",
 			mcp.Description("Number of records to return"),
 		),
+		includeLabelsOption(),
 	)
-	s.AddTool(tokenTransfersByAddressTool, func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	tools.add(tokenTransfersByAddressTool, withLabels(client, func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
 		return handleGetTokenTransfersByAddress(ctx, request, client)
-	})
+	}))
 
 	// 14. Get ERC721 Transfers
 	erc721TransfersTool := mcp.NewTool("getERC721Transfers",
@@ -362,10 +588,11 @@ func RegisterTools(s *server.MCPServer, client *etherscan.Client, rpcClient *rpc
 		mcp.WithString("offset",
 			mcp.Description("Number of records to return"),
 		),
+		includeLabelsOption(),
 	)
-	s.AddTool(erc721TransfersTool, func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	tools.add(erc721TransfersTool, withLabels(client, func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
 		return handleGetERC721Transfers(ctx, request, client)
-	})
+	}))
 
 	// Add new tool registration for getLatestBlockNumber
 	latestBlockNumberTool := mcp.NewTool("getLatestBlockNumber",
@@ -375,7 +602,591 @@ func RegisterTools(s *server.MCPServer, client *etherscan.Client, rpcClient *rpc
 			mcp.Description("The chain ID (e.g., 1 for Ethereum, 42161 for Arbitrum)"),
 		),
 	)
-	s.AddTool(latestBlockNumberTool, func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	tools.add(latestBlockNumberTool, func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
 		return handleGetLatestBlockNumber(ctx, request, client, rpcClient)
 	})
+
+	// 15. Get Mined Blocks
+	minedBlocksTool := mcp.NewTool("getMinedBlocks",
+		mcp.WithDescription("Get list of blocks validated/mined by an address, with block count and total rewards (in wei) for the returned page"),
+		mcp.WithString("chainID",
+			mcp.Required(),
+			mcp.Description("The chain ID (e.g., 1 for Ethereum)"),
+		),
+		mcp.WithString("address",
+			mcp.Required(),
+			mcp.Description("The validator/miner address"),
+		),
+		mcp.WithString("blockType",
+			mcp.Description("'blocks' for canonical blocks or 'uncles' for uncle blocks (default: 'blocks')"),
+			mcp.Enum("blocks", "uncles"),
+		),
+		mcp.WithString("page",
+			mcp.Description("Page number"),
+		),
+		mcp.WithString("offset",
+			mcp.Description("Number of records to return"),
+		),
+	)
+	tools.add(minedBlocksTool, func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		return handleGetMinedBlocks(ctx, request, client)
+	})
+
+	// 16. Get Beacon Chain Withdrawals
+	beaconWithdrawalsTool := mcp.NewTool("getBeaconWithdrawals",
+		mcp.WithDescription("Get beacon chain withdrawals made to an address by block range"),
+		chainSpecificChainIDOption("txsBeaconWithdrawal"),
+		mcp.WithString("address",
+			mcp.Required(),
+			mcp.Description("The withdrawal recipient address"),
+		),
+		mcp.WithString("startBlock",
+			mcp.Description("Starting block number"),
+		),
+		mcp.WithString("endBlock",
+			mcp.Description("Ending block number"),
+		),
+		mcp.WithString("page",
+			mcp.Description("Page number"),
+		),
+		mcp.WithString("offset",
+			mcp.Description("Number of records to return"),
+		),
+		mcp.WithString("sort",
+			mcp.Description("Sort order by block number: 'asc' or 'desc'"),
+			mcp.Enum("asc", "desc"),
+		),
+	)
+	tools.add(beaconWithdrawalsTool, func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		return handleGetBeaconWithdrawals(ctx, request, client)
+	})
+
+	// 17. Get Polygon Plasma Deposits
+	plasmaDepositsTool := mcp.NewTool("getPlasmaDeposits",
+		mcp.WithDescription("Get Polygon plasma bridge deposits by address"),
+		chainSpecificChainIDOption("txnbridge"),
+		mcp.WithString("address",
+			mcp.Required(),
+			mcp.Description("The account address"),
+		),
+		mcp.WithString("page",
+			mcp.Description("Page number"),
+		),
+		mcp.WithString("offset",
+			mcp.Description("Number of records to return"),
+		),
+	)
+	tools.add(plasmaDepositsTool, func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		return handleGetPlasmaDeposits(ctx, request, client)
+	})
+
+	// 18. Get L1 -> L2 Deposit Transactions
+	depositTransactionsTool := mcp.NewTool("getDepositTransactions",
+		mcp.WithDescription("Get L1 to L2 bridge deposit transactions by address on a rollup chain"),
+		chainSpecificChainIDOption("getdeposittxs"),
+		mcp.WithString("address",
+			mcp.Required(),
+			mcp.Description("The account address"),
+		),
+		mcp.WithString("page",
+			mcp.Description("Page number"),
+		),
+		mcp.WithString("offset",
+			mcp.Description("Number of records to return"),
+		),
+		mcp.WithString("sort",
+			mcp.Description("Sort order by block number: 'asc' or 'desc'"),
+			mcp.Enum("asc", "desc"),
+		),
+	)
+	tools.add(depositTransactionsTool, func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		return handleGetDepositTransactions(ctx, request, client)
+	})
+
+	// 19. Get Withdrawal Transactions
+	withdrawalTransactionsTool := mcp.NewTool("getWithdrawalTransactions",
+		mcp.WithDescription("Get withdrawal transactions by address"),
+		chainSpecificChainIDOption("getwithdrawaltxs"),
+		mcp.WithString("address",
+			mcp.Required(),
+			mcp.Description("The account address"),
+		),
+		mcp.WithString("page",
+			mcp.Description("Page number"),
+		),
+		mcp.WithString("offset",
+			mcp.Description("Number of records to return"),
+		),
+		mcp.WithString("sort",
+			mcp.Description("Sort order by block number: 'asc' or 'desc'"),
+			mcp.Enum("asc", "desc"),
+		),
+	)
+	tools.add(withdrawalTransactionsTool, func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		return handleGetWithdrawalTransactions(ctx, request, client)
+	})
+
+	// 20. Get Native Token Supply
+	ethSupplyTool := mcp.NewTool("getEthSupply",
+		mcp.WithDescription("Get the total supply of the chain's native token in wei"),
+		mcp.WithString("chainID",
+			mcp.Required(),
+			mcp.Description("The chain ID (e.g., 1 for Ethereum)"),
+		),
+		mcp.WithBoolean("detailed",
+			mcp.Description("Include staking rewards, burnt fees and withdrawn totals (default: false)"),
+		),
+	)
+	tools.add(ethSupplyTool, func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		return handleGetEthSupply(ctx, request, client)
+	})
+
+	// 21. Get Native Token Price
+	ethPriceTool := mcp.NewTool("getEthPrice",
+		mcp.WithDescription("Get the latest price of the chain's native token in BTC and USD"),
+		mcp.WithString("chainID",
+			mcp.Required(),
+			mcp.Description("The chain ID (e.g., 1 for Ethereum)"),
+		),
+	)
+	tools.add(ethPriceTool, func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		return handleGetEthPrice(ctx, request, client)
+	})
+
+	// 22. Get Chain Size
+	chainSizeTool := mcp.NewTool("getChainSize",
+		mcp.WithDescription("Get the size of the blockchain in bytes over a date range"),
+		mcp.WithString("chainID",
+			mcp.Required(),
+			mcp.Description("The chain ID (e.g., 1 for Ethereum)"),
+		),
+		mcp.WithString("startDate",
+			mcp.Required(),
+			mcp.Description("Starting date in yyyy-MM-dd format"),
+		),
+		mcp.WithString("endDate",
+			mcp.Required(),
+			mcp.Description("Ending date in yyyy-MM-dd format"),
+		),
+		mcp.WithString("clientType",
+			mcp.Description("Ethereum node client (default: 'geth')"),
+			mcp.Enum("geth", "parity"),
+		),
+		mcp.WithString("syncMode",
+			mcp.Description("Node sync mode (default: 'default')"),
+			mcp.Enum("default", "archive"),
+		),
+		mcp.WithString("sort",
+			mcp.Description("Sort order by date: 'asc' or 'desc'"),
+			mcp.Enum("asc", "desc"),
+		),
+	)
+	tools.add(chainSizeTool, func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		return handleGetChainSize(ctx, request, client)
+	})
+
+	// 23. Get Node Count
+	nodeCountTool := mcp.NewTool("getNodeCount",
+		mcp.WithDescription("Get the total number of discoverable nodes on the network"),
+		mcp.WithString("chainID",
+			mcp.Required(),
+			mcp.Description("The chain ID (e.g., 1 for Ethereum)"),
+		),
+	)
+	tools.add(nodeCountTool, func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		return handleGetNodeCount(ctx, request, client)
+	})
+
+	// 24. Get Daily Statistics
+	dailyStatsTool := mcp.NewTool("getDailyStats",
+		mcp.WithDescription("Get a daily network statistics series (transactions, gas, fees, blocks, price) over a date range. Requires an Etherscan API Pro plan"),
+		mcp.WithString("chainID",
+			mcp.Required(),
+			mcp.Description("The chain ID (e.g., 1 for Ethereum)"),
+		),
+		mcp.WithString("metric",
+			mcp.Required(),
+			mcp.Description("The daily metric to retrieve"),
+			mcp.Enum(etherscan.DailyStatsActions...),
+		),
+		mcp.WithString("startDate",
+			mcp.Required(),
+			mcp.Description("Starting date in yyyy-MM-dd format"),
+		),
+		mcp.WithString("endDate",
+			mcp.Required(),
+			mcp.Description("Ending date in yyyy-MM-dd format"),
+		),
+		mcp.WithString("sort",
+			mcp.Description("Sort order by date: 'asc' or 'desc'"),
+			mcp.Enum("asc", "desc"),
+		),
+	)
+	tools.add(dailyStatsTool, func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		return handleGetDailyStats(ctx, request, client)
+	})
+
+	// 25. Get Token Holder Count
+	tokenHolderCountTool := mcp.NewTool("getTokenHolderCount",
+		mcp.WithDescription("Get the number of addresses holding an ERC20 token"),
+		mcp.WithString("chainID",
+			mcp.Required(),
+			mcp.Description("The chain ID (e.g., 1 for Ethereum)"),
+		),
+		mcp.WithString("contractAddress",
+			mcp.Required(),
+			mcp.Description("The token contract address"),
+		),
+	)
+	tools.add(tokenHolderCountTool, func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		return handleGetTokenHolderCount(ctx, request, client)
+	})
+
+	// 26. Get Top Token Holders
+	topHoldersTool := mcp.NewTool("getTopHolders",
+		mcp.WithDescription("Get the largest holders of an ERC20 token with balances scaled by decimals, share of total supply, and concentration metrics (top-10 share, Gini coefficient). Holders are ranked by balance within a sample of at least 1000 holders from the explorer, which is noted when the sample may not contain every holder"),
+		mcp.WithString("chainID",
+			mcp.Required(),
+			mcp.Description("The chain ID (e.g., 1 for Ethereum)"),
+		),
+		mcp.WithString("contractAddress",
+			mcp.Required(),
+			mcp.Description("The token contract address"),
+		),
+		mcp.WithString("limit",
+			mcp.Description("Number of top holders to return (default: 50)"),
+		),
+	)
+	tools.add(topHoldersTool, func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		return handleGetTopHolders(ctx, request, client, rpcClient)
+	})
+
+	// 27. Get Token Supply
+	tokenSupplyTool := mcp.NewTool("getTokenSupply",
+		mcp.WithDescription("Get the total and circulating supply of an ERC20 token, raw and adjusted for decimals, optionally at a historical block"),
+		mcp.WithString("chainID",
+			mcp.Required(),
+			mcp.Description("The chain ID (e.g., 1 for Ethereum)"),
+		),
+		mcp.WithString("contractAddress",
+			mcp.Required(),
+			mcp.Description("The token contract address"),
+		),
+		mcp.WithString("blockNumber",
+			mcp.Description("Block number for historical supply (default: 'latest')"),
+		),
+		mcp.WithString("excludeAddresses",
+			mcp.Description("Comma-separated addresses whose balances are excluded from circulating supply (default: zero and 0x...dEaD burn addresses; 'none' excludes nothing). Balances that can't be read are reported as unavailable"),
+		),
+	)
+	tools.add(tokenSupplyTool, func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		return handleGetTokenSupply(ctx, request, client, rpcClient)
+	})
+
+	// 28. Get Address Label
+	addressLabelTool := mcp.NewTool("getAddressLabel",
+		mcp.WithDescription("Get the Etherscan name tag and labels (exchange, bridge, exploiter, etc.) for one or more addresses"),
+		mcp.WithString("chainID",
+			mcp.Required(),
+			mcp.Description("The chain ID (e.g., 1 for Ethereum)"),
+		),
+		mcp.WithString("address",
+			mcp.Required(),
+			mcp.Description("The address, or comma-separated addresses"),
+		),
+	)
+	tools.add(addressLabelTool, func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		return handleGetAddressLabel(ctx, request, client)
+	})
+
+	// 29. Verify Contract Source
+	verifySourceTool := mcp.NewTool("verifyContractSource",
+		mcp.WithDescription("Submit Solidity standard JSON input for source code verification and wait for the result, sending progress notifications while polling"),
+		mcp.WithString("chainID",
+			mcp.Required(),
+			mcp.Description("The chain ID (e.g., 1 for Ethereum)"),
+		),
+		mcp.WithString("contractAddress",
+			mcp.Required(),
+			mcp.Description("The deployed contract address"),
+		),
+		mcp.WithString("contractName",
+			mcp.Required(),
+			mcp.Description("Fully qualified contract name, e.g. 'contracts/Token.sol:Token'"),
+		),
+		mcp.WithString("compilerVersion",
+			mcp.Required(),
+			mcp.Description("Full compiler version, e.g. 'v0.8.24+commit.e11b9ed9'"),
+		),
+		mcp.WithString("standardJsonInput",
+			mcp.Required(),
+			mcp.Description("The Solidity standard JSON input used to compile the contract"),
+		),
+		mcp.WithString("constructorArguments",
+			mcp.Description("ABI-encoded constructor arguments as hex"),
+		),
+		mcp.WithString("licenseType",
+			mcp.Description("Etherscan license type number (e.g., 3 for MIT)"),
+		),
+		mcp.WithString("timeoutSeconds",
+			mcp.Description("How long to wait for the result before returning a pending status (default: 120)"),
+		),
+	)
+	tools.add(verifySourceTool, func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		return handleVerifyContractSource(ctx, request, client)
+	})
+
+	// 30. Verify Proxy Contract
+	verifyProxyTool := mcp.NewTool("verifyProxyContract",
+		mcp.WithDescription("Ask Etherscan to detect a proxy's implementation and link them, waiting for the result"),
+		mcp.WithString("chainID",
+			mcp.Required(),
+			mcp.Description("The chain ID (e.g., 1 for Ethereum)"),
+		),
+		mcp.WithString("contractAddress",
+			mcp.Required(),
+			mcp.Description("The proxy contract address"),
+		),
+		mcp.WithString("expectedImplementation",
+			mcp.Description("Implementation address the proxy is expected to point to"),
+		),
+		mcp.WithString("timeoutSeconds",
+			mcp.Description("How long to wait for the result before returning a pending status (default: 120)"),
+		),
+	)
+	tools.add(verifyProxyTool, func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		return handleVerifyProxyContract(ctx, request, client)
+	})
+
+	// 31. Check Verification Status
+	verificationStatusTool := mcp.NewTool("checkVerificationStatus",
+		mcp.WithDescription("Check the status of a source code or proxy verification submission by GUID"),
+		mcp.WithString("chainID",
+			mcp.Required(),
+			mcp.Description("The chain ID (e.g., 1 for Ethereum)"),
+		),
+		mcp.WithString("guid",
+			mcp.Required(),
+			mcp.Description("The GUID returned when the verification was submitted"),
+		),
+		mcp.WithBoolean("proxy",
+			mcp.Description("Whether the GUID belongs to a proxy verification (default: false)"),
+		),
+	)
+	tools.add(verificationStatusTool, func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		return handleCheckVerificationStatus(ctx, request, client)
+	})
+
+	// 32. Simulate Transaction
+	simulateTransactionTool := mcp.NewTool("simulateTransaction",
+		mcp.WithDescription("Simulate a transaction with eth_call and eth_estimateGas at a chosen block, optionally with state overrides. Returns success or the decoded revert reason, decoded return data, and gas used as estimated by eth_estimateGas. Requires an RPC endpoint"),
+		mcp.WithString("chainID",
+			mcp.Required(),
+			mcp.Description("The chain ID (e.g., 1 for Ethereum)"),
+		),
+		mcp.WithString("to",
+			mcp.Required(),
+			mcp.Description("The recipient or contract address"),
+		),
+		mcp.WithString("from",
+			mcp.Description("The sender address"),
+		),
+		mcp.WithString("data",
+			mcp.Description("The call data as hex"),
+		),
+		mcp.WithString("value",
+			mcp.Description("The value to send in wei (decimal or hex)"),
+		),
+		mcp.WithString("gas",
+			mcp.Description("The gas limit (decimal or hex)"),
+		),
+		mcp.WithString("blockNumber",
+			mcp.Description("Block number or tag to simulate against (default: 'latest')"),
+		),
+		mcp.WithString("stateOverrides",
+			mcp.Description(stateOverridesDescription),
+		),
+	)
+	tools.add(simulateTransactionTool, func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		return handleSimulateTransaction(ctx, request, client, rpcClient)
+	})
+
+	// 33. Get Transaction Trace
+	transactionTraceTool := mcp.NewTool("getTransactionTrace",
+		mcp.WithDescription("Get the call tree of a transaction (type, from, to, value, method, gas, errors and revert reasons) via debug_traceTransaction or trace_transaction. Requires an RPC endpoint that exposes the debug or trace namespace"),
+		mcp.WithString("chainID",
+			mcp.Required(),
+			mcp.Description("The chain ID (e.g., 1 for Ethereum)"),
+		),
+		mcp.WithString("txHash",
+			mcp.Required(),
+			mcp.Description("The transaction hash"),
+		),
+		mcp.WithString("maxDepth",
+			mcp.Description("Maximum call depth to return; deeper calls are counted in the collapsedCalls of the frame they were cut from (default: 8)"),
+		),
+		mcp.WithBoolean("hideStaticCalls",
+			mcp.Description("Collapse successful STATICCALLs (view calls) into the collapsedCalls count of their caller (default: false)"),
+		),
+		mcp.WithBoolean("decodeArguments",
+			mcp.Description("Include decoded arguments of each call when the method is known (default: false)"),
+		),
+	)
+	tools.add(transactionTraceTool, func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		return handleGetTransactionTrace(ctx, request, client, rpcClient)
+	})
+
+	// 34. Get State Diff
+	stateDiffTool := mcp.NewTool("getStateDiff",
+		mcp.WithDescription("Get the state changes made by a transaction per address: native balance, nonce, code, storage slot writes, and ERC20 balance changes derived from Transfer logs. Requires an RPC endpoint that exposes the debug or trace namespace"),
+		mcp.WithString("chainID",
+			mcp.Required(),
+			mcp.Description("The chain ID (e.g., 1 for Ethereum)"),
+		),
+		mcp.WithString("txHash",
+			mcp.Required(),
+			mcp.Description("The transaction hash"),
+		),
+	)
+	tools.add(stateDiffTool, func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		return handleGetStateDiff(ctx, request, client, rpcClient)
+	})
+
+	// 35. Explain Transaction
+	explainTransactionTool := mcp.NewTool("explainTransaction",
+		mcp.WithDescription("Explain a transaction in one structured summary: who called what, decoded input and events, internal transactions, token transfers, net assets moved in and out per address (excluding fees), fees in native token (including the L1 data fee on OP-stack chains) and in USD at the current price, and the revert reason of failed transactions"),
+		mcp.WithString("chainID",
+			mcp.Required(),
+			mcp.Description("The chain ID (e.g., 1 for Ethereum)"),
+		),
+		mcp.WithString("txHash",
+			mcp.Required(),
+			mcp.Description("The transaction hash"),
+		),
+		includeLabelsOption(),
+	)
+	tools.add(explainTransactionTool, withLabels(client, func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		return handleExplainTransaction(ctx, request, client, rpcClient)
+	}))
+
+	// 36. List Chains
+	listChainsTool := mcp.NewTool("listChains",
+		mcp.WithDescription("List the chains known to the server with their chain IDs, names and aliases accepted by every chainID parameter, native currency, explorer, block time, L1/L2 relation, wrapped native token, Multicall3 address, RPC availability and chain-specific tools"),
+	)
+	tools.add(listChainsTool, func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		return handleListChains(ctx, request, client)
+	})
+
+	// 37. Get Chain Capabilities
+	chainCapabilitiesTool := mcp.NewTool("getChainCapabilities",
+		mcp.WithDescription("Get which explorer endpoints a chain supports (from the per-chain capability matrix), their tier and fallbacks, whether an RPC endpoint is configured, and which tools can't answer on the chain"),
+		mcp.WithString("chainID",
+			mcp.Required(),
+			mcp.Description("The chain ID (e.g., 1 for Ethereum)"),
+		),
+	)
+	tools.add(chainCapabilitiesTool, func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		return handleGetChainCapabilities(ctx, request, client)
+	})
+
+	// 38. Get Logs
+	logsTool := mcp.NewTool("getLogs",
+		mcp.WithDescription("Get the event logs emitted in a block range, filtered by contract address and/or topics (all given topics must match). Etherscan returns at most 1000 logs per call; RPC endpoints may limit the block range"),
+		mcp.WithString("chainID",
+			mcp.Required(),
+			mcp.Description("The chain ID (e.g., 1 for Ethereum)"),
+		),
+		mcp.WithString("fromBlock",
+			mcp.Required(),
+			mcp.Description("The first block of the range"),
+		),
+		mcp.WithString("toBlock",
+			mcp.Description("The last block of the range (default: 'latest')"),
+		),
+		mcp.WithString("address",
+			mcp.Description("The contract that emitted the logs"),
+		),
+		mcp.WithString("topic0",
+			mcp.Description("The event signature hash (e.g. 0xddf252ad... for Transfer)"),
+		),
+		mcp.WithString("topic1",
+			mcp.Description("The first indexed event parameter, as a 32-byte hex value"),
+		),
+		mcp.WithString("topic2",
+			mcp.Description("The second indexed event parameter, as a 32-byte hex value"),
+		),
+		mcp.WithString("topic3",
+			mcp.Description("The third indexed event parameter, as a 32-byte hex value"),
+		),
+		includeLabelsOption(),
+	)
+	tools.add(logsTool, withLabels(client, func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		return handleGetLogs(ctx, request, client, rpcClient)
+	}))
+}
+
+// RegisterWriteTools registers the tools that change chain state. They are only registered
+// when explicitly enabled, so a default deployment stays read-only.
+func RegisterWriteTools(s *server.MCPServer, client etherscan.Explorer, rpcClient *rpc.Client) {
+	tools := newToolRegistrar(s, client, rpcClient)
+
+	// 1. Send Raw Transaction
+	sendRawTransactionTool := mcp.NewTool("sendRawTransaction",
+		mcp.WithDescription("Decode a signed transaction (to, value, nonce, chain ID, decoded calldata) and, when broadcast is true, send it to the network. Transactions signed for another chain or without EIP-155 replay protection are rejected"),
+		mcp.WithString("chainID",
+			mcp.Required(),
+			mcp.Description("The chain ID (e.g., 1 for Ethereum); must match the chain ID in the signed transaction"),
+		),
+		mcp.WithString("signedTransaction",
+			mcp.Required(),
+			mcp.Description("The RLP-encoded signed transaction as hex"),
+		),
+		mcp.WithBoolean("broadcast",
+			mcp.Description("Send the transaction after decoding it; when false only the decoded transaction is returned (default: false)"),
+		),
+	)
+	tools.add(sendRawTransactionTool, func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		return handleSendRawTransaction(ctx, request, client, rpcClient)
+	})
+}
+
+// toolRegistrar adds tools to the MCP server together with the behavior shared by every tool
+type toolRegistrar struct {
+	server    *server.MCPServer
+	client    etherscan.Explorer
+	rpcClient *rpc.Client
+}
+
+func newToolRegistrar(s *server.MCPServer, client etherscan.Explorer, rpcClient *rpc.Client) *toolRegistrar {
+	return &toolRegistrar{server: s, client: client, rpcClient: rpcClient}
+}
+
+// add registers a tool, normalizing its output unless the tool returns no amounts and resolving
+// chain names passed as chainID. In RPC-only mode, tools that need Etherscan are skipped.
+func (r *toolRegistrar) add(tool mcp.Tool, handler server.ToolHandlerFunc) {
+	if rpcOnlyMode && !rpcCapableTools[tool.Name] {
+		disabledTools = append(disabledTools, tool.Name)
+		return
+	}
+	if !unnormalizedTools[tool.Name] {
+		tool = withRawOption(tool)
+		handler = withNormalization(r.client, r.rpcClient, tool.Name, handler)
+	}
+	r.server.AddTool(withChainNameHint(tool), withChainResolution(handler))
+}
+
+// slotPathDescription documents the storage layout path accepted by the storage tools
+const slotPathDescription = `JSON array of layout steps applied to the slot, e.g. [{"type":"mapping","keyType":"address","key":"0x..."},{"type":"field","offset":1}]. ` +
+	`Step types: mapping (keyType, key), dynamicArray (index, elementSlots), fixedArray (index, elementSlots), field (offset)`
+
+// chainSpecificChainIDOption builds a chainID parameter documenting the chains that serve an account action
+func chainSpecificChainIDOption(action string) mcp.ToolOption {
+	var supported []string
+	for _, chainID := range etherscan.SupportedChains("account", action) {
+		supported = append(supported, chains.Label(chainID))
+	}
+	return mcp.WithString("chainID",
+		mcp.Required(),
+		mcp.Description(fmt.Sprintf("The chain ID (supported: %s)", strings.Join(supported, ", "))),
+	)
 }
@@ -0,0 +1,287 @@
+package mcp
+
+import (
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"log"
+	"strings"
+
+	"github.com/huahuayu/etherscan-mcp-server/internal/abi"
+	"github.com/huahuayu/etherscan-mcp-server/internal/etherscan"
+	"github.com/huahuayu/etherscan-mcp-server/internal/rpc"
+	"github.com/huahuayu/etherscan-mcp-server/internal/units"
+)
+
+const (
+	// defaultTraceDepth is the call depth returned when maxDepth isn't given
+	defaultTraceDepth = 8
+
+	// maxTraceABILookups caps the uncached verified ABI lookups made to decode a single trace
+	maxTraceABILookups = 20
+)
+
+// callFrame is a normalized call tree node, independent of the tracer that produced it
+type callFrame struct {
+	Type           string                `json:"type"`
+	From           string                `json:"from"`
+	To             string                `json:"to,omitempty"`
+	Value          string                `json:"value,omitempty"`
+	Gas            string                `json:"gas,omitempty"`
+	GasUsed        string                `json:"gasUsed,omitempty"`
+	Selector       string                `json:"selector,omitempty"`
+	Method         string                `json:"method,omitempty"`
+	Arguments      []abi.DecodedArgument `json:"arguments,omitempty"`
+	Error          string                `json:"error,omitempty"`
+	RevertReason   string                `json:"revertReason,omitempty"`
+	Calls          []*callFrame          `json:"calls,omitempty"`
+	CollapsedCalls int                   `json:"collapsedCalls,omitempty"`
+
+	input  []byte
+	output []byte
+}
+
+// geth callTracer frame
+type gethCallFrame struct {
+	Type         string          `json:"type"`
+	From         string          `json:"from"`
+	To           string          `json:"to"`
+	Value        string          `json:"value"`
+	Gas          string          `json:"gas"`
+	GasUsed      string          `json:"gasUsed"`
+	Input        string          `json:"input"`
+	Output       string          `json:"output"`
+	Error        string          `json:"error"`
+	RevertReason string          `json:"revertReason"`
+	Calls        []gethCallFrame `json:"calls"`
+}
+
+// parityTrace is a flat trace_transaction entry
+type parityTrace struct {
+	Type   string `json:"type"`
+	Action struct {
+		CallType      string `json:"callType"`
+		From          string `json:"from"`
+		To            string `json:"to"`
+		Value         string `json:"value"`
+		Gas           string `json:"gas"`
+		Input         string `json:"input"`
+		Init          string `json:"init"`
+		Address       string `json:"address"`
+		RefundAddress string `json:"refundAddress"`
+		Balance       string `json:"balance"`
+	} `json:"action"`
+	Result *struct {
+		GasUsed string `json:"gasUsed"`
+		Output  string `json:"output"`
+		Address string `json:"address"`
+	} `json:"result"`
+	Error        string `json:"error"`
+	TraceAddress []int  `json:"traceAddress"`
+}
+
+// traceOptions control how a call tree is pruned and decoded
+type traceOptions struct {
+	maxDepth        int
+	hideStaticCalls bool
+	decodeArguments bool
+}
+
+// traceSummary describes the whole call tree before pruning
+type traceSummary struct {
+	TotalCalls  int `json:"totalCalls"`
+	FailedCalls int `json:"failedCalls"`
+	MaxDepth    int `json:"maxDepth"`
+	HiddenCalls int `json:"hiddenCalls"`
+}
+
+// traceCallTree gets the call tree of a transaction, preferring debug_traceTransaction's callTracer
+// and falling back to trace_transaction. Returns the tree and the tracer used.
+func traceCallTree(rpcClient *rpc.Client, chainID, txHash string) (*callFrame, string, error) {
+	result, err := rpcClient.DebugTraceTransaction(chainID, txHash, map[string]interface{}{"tracer": "callTracer"})
+	if err == nil {
+		var root gethCallFrame
+		if err := json.Unmarshal(result, &root); err != nil {
+			return nil, "", fmt.Errorf("failed to parse call trace: %w", err)
+		}
+		return fromGethFrame(root), "callTracer", nil
+	}
+	log.Printf("debug_traceTransaction unavailable for chain %s (%v), trying trace_transaction", chainID, err)
+
+	result, traceErr := rpcClient.TraceTransaction(chainID, txHash)
+	if traceErr != nil {
+		return nil, "", fmt.Errorf("the RPC endpoint for chain %s supports neither debug_traceTransaction (%v) nor trace_transaction (%v)", chainID, err, traceErr)
+	}
+
+	var traces []parityTrace
+	if err := json.Unmarshal(result, &traces); err != nil {
+		return nil, "", fmt.Errorf("failed to parse transaction trace: %w", err)
+	}
+	root, err := fromParityTraces(traces)
+	if err != nil {
+		return nil, "", err
+	}
+	return root, "trace_transaction", nil
+}
+
+func fromGethFrame(frame gethCallFrame) *callFrame {
+	node := &callFrame{
+		Type:         strings.ToUpper(frame.Type),
+		From:         frame.From,
+		To:           frame.To,
+		Value:        decimalQuantity(frame.Value),
+		Gas:          decimalQuantity(frame.Gas),
+		GasUsed:      decimalQuantity(frame.GasUsed),
+		Error:        frame.Error,
+		RevertReason: frame.RevertReason,
+		input:        decodeHexData(frame.Input),
+		output:       decodeHexData(frame.Output),
+	}
+	for _, child := range frame.Calls {
+		node.Calls = append(node.Calls, fromGethFrame(child))
+	}
+	return node
+}
+
+// fromParityTraces rebuilds the call tree from flat traces using their trace addresses
+func fromParityTraces(traces []parityTrace) (*callFrame, error) {
+	var root *callFrame
+	nodes := make(map[string]*callFrame)
+
+	for _, trace := range traces {
+		if trace.Type == "reward" {
+			continue
+		}
+
+		node := &callFrame{
+			From:  trace.Action.From,
+			To:    trace.Action.To,
+			Value: decimalQuantity(trace.Action.Value),
+			Gas:   decimalQuantity(trace.Action.Gas),
+			Error: trace.Error,
+			input: decodeHexData(trace.Action.Input),
+		}
+		switch trace.Type {
+		case "create":
+			node.Type = "CREATE"
+			node.input = decodeHexData(trace.Action.Init)
+		case "suicide":
+			node.Type = "SELFDESTRUCT"
+			node.From = trace.Action.Address
+			node.To = trace.Action.RefundAddress
+			node.Value = decimalQuantity(trace.Action.Balance)
+		default:
+			node.Type = strings.ToUpper(trace.Action.CallType)
+		}
+		if trace.Result != nil {
+			node.GasUsed = decimalQuantity(trace.Result.GasUsed)
+			node.output = decodeHexData(trace.Result.Output)
+			if trace.Result.Address != "" {
+				node.To = trace.Result.Address
+			}
+		}
+
+		key := fmt.Sprint(trace.TraceAddress)
+		nodes[key] = node
+		if len(trace.TraceAddress) == 0 {
+			root = node
+			continue
+		}
+		parent, ok := nodes[fmt.Sprint(trace.TraceAddress[:len(trace.TraceAddress)-1])]
+		if !ok {
+			return nil, fmt.Errorf("trace %v has no parent", trace.TraceAddress)
+		}
+		parent.Calls = append(parent.Calls, node)
+	}
+
+	if root == nil {
+		return nil, fmt.Errorf("transaction trace is empty")
+	}
+	return root, nil
+}
+
+// summarizeTrace counts the calls of a tree
+func summarizeTrace(node *callFrame, depth int, summary *traceSummary) {
+	summary.TotalCalls++
+	if node.Error != "" {
+		summary.FailedCalls++
+	}
+	if depth > summary.MaxDepth {
+		summary.MaxDepth = depth
+	}
+	for _, child := range node.Calls {
+		summarizeTrace(child, depth+1, summary)
+	}
+}
+
+// pruneTrace applies the depth limit and hides successful static calls. Each frame's collapsedCalls
+// counts the calls cut directly under it, including their descendants, so that the counts over the
+// tree add up to the calls removed. Returns the number of calls removed below node.
+func pruneTrace(node *callFrame, depth int, options traceOptions) int {
+	collapsed, removed := 0, 0
+	var kept []*callFrame
+	for _, child := range node.Calls {
+		if depth+1 > options.maxDepth || (options.hideStaticCalls && child.Type == "STATICCALL" && child.Error == "") {
+			collapsed += countCalls(child)
+			continue
+		}
+		removed += pruneTrace(child, depth+1, options)
+		kept = append(kept, child)
+	}
+	node.Calls = kept
+	node.CollapsedCalls = collapsed
+	return collapsed + removed
+}
+
+// countCalls counts a frame and all its descendants
+func countCalls(node *callFrame) int {
+	count := 1
+	for _, child := range node.Calls {
+		count += countCalls(child)
+	}
+	return count
+}
+
+// decodeTrace resolves method names, optional arguments and revert reasons of the remaining frames
+func decodeTrace(node *callFrame, resolver *methodResolver, options traceOptions) {
+	if len(node.input) >= 4 && node.Type != "CREATE" && node.Type != "CREATE2" {
+		node.Selector = abi.Selector(node.input)
+		if method, _ := resolver.resolve(node.To, node.input); method != nil {
+			node.Method = method.Signature
+			if options.decodeArguments {
+				node.Arguments, _ = method.DecodeInput(node.input)
+			}
+		}
+	}
+	if node.Error != "" && node.RevertReason == "" && len(node.output) > 0 {
+		if revert := abi.DecodeRevert(node.output, nil); revert.Reason != "" {
+			node.RevertReason = revert.Reason
+		}
+	}
+	for _, child := range node.Calls {
+		decodeTrace(child, resolver, options)
+	}
+}
+
+// newTraceResolver creates the method resolver used to decode a trace
+func newTraceResolver(client etherscan.Explorer, chainID string) *methodResolver {
+	return &methodResolver{client: client, chainID: chainID, lookups: maxTraceABILookups}
+}
+
+// decimalQuantity converts a hex quantity to decimal, returning "" for empty or zero values
+func decimalQuantity(value string) string {
+	quantity, ok := units.ParseBigInt(value)
+	if !ok || quantity.Sign() == 0 {
+		return ""
+	}
+	return quantity.String()
+}
+
+// decodeHexData decodes 0x-prefixed hex data, returning nil for invalid input
+func decodeHexData(value string) []byte {
+	data, err := hex.DecodeString(trimHexPrefix(value))
+	if err != nil {
+		return nil
+	}
+	return data
+}
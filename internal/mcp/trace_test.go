@@ -0,0 +1,169 @@
+package mcp
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+)
+
+func TestFromParityTraces(t *testing.T) {
+	tests := []struct {
+		name    string
+		traces  string
+		want    string
+		wantErr string
+	}{
+		{
+			name: "nested calls from trace addresses",
+			traces: `[
+				{"type":"call","action":{"callType":"call","from":"0xa","to":"0xb","value":"0xde0b6b3a7640000","gas":"0x5208"},"result":{"gasUsed":"0x5000"},"traceAddress":[]},
+				{"type":"call","action":{"callType":"staticcall","from":"0xb","to":"0xc"},"result":{},"traceAddress":[0]},
+				{"type":"call","action":{"callType":"delegatecall","from":"0xb","to":"0xd"},"error":"Reverted","traceAddress":[1]},
+				{"type":"call","action":{"callType":"call","from":"0xd","to":"0xe"},"result":{},"traceAddress":[1,0]}
+			]`,
+			want: `{"type":"CALL","from":"0xa","to":"0xb","value":"1000000000000000000","gas":"21000","gasUsed":"20480","calls":[
+				{"type":"STATICCALL","from":"0xb","to":"0xc"},
+				{"type":"DELEGATECALL","from":"0xb","to":"0xd","error":"Reverted","calls":[{"type":"CALL","from":"0xd","to":"0xe"}]}
+			]}`,
+		},
+		{
+			name: "create and selfdestruct with rewards skipped",
+			traces: `[
+				{"type":"call","action":{"callType":"call","from":"0xa","to":"0xb"},"result":{},"traceAddress":[]},
+				{"type":"create","action":{"from":"0xb","init":"0x6080","value":"0x0"},"result":{"address":"0xc"},"traceAddress":[0]},
+				{"type":"suicide","action":{"address":"0xc","refundAddress":"0xa","balance":"0x1"},"traceAddress":[0,0]},
+				{"type":"reward","action":{"author":"0xf"},"traceAddress":[]}
+			]`,
+			want: `{"type":"CALL","from":"0xa","to":"0xb","calls":[
+				{"type":"CREATE","from":"0xb","to":"0xc","calls":[{"type":"SELFDESTRUCT","from":"0xc","to":"0xa","value":"1"}]}
+			]}`,
+		},
+		{
+			name: "orphan trace",
+			traces: `[
+				{"type":"call","action":{"callType":"call","from":"0xa","to":"0xb"},"traceAddress":[]},
+				{"type":"call","action":{"callType":"call","from":"0xb","to":"0xc"},"traceAddress":[1,0]}
+			]`,
+			wantErr: "has no parent",
+		},
+		{
+			name:    "empty trace",
+			traces:  `[]`,
+			wantErr: "trace is empty",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var traces []parityTrace
+			if err := json.Unmarshal([]byte(tt.traces), &traces); err != nil {
+				t.Fatalf("invalid test traces: %v", err)
+			}
+			root, err := fromParityTraces(traces)
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("fromParityTraces() error = %v, want %q", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("fromParityTraces() error = %v", err)
+			}
+			got, _ := json.Marshal(root)
+			if !jsonEqual(t, string(got), tt.want) {
+				t.Errorf("got %s, want %s", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestPruneTrace(t *testing.T) {
+	// A calls B, which makes a static call to C (calling D) and a call to E (calling F, calling G)
+	trace := `{"type":"call","from":"0xa","to":"0xb","calls":[
+		{"type":"staticcall","from":"0xb","to":"0xc","calls":[{"type":"call","from":"0xc","to":"0xd"}]},
+		{"type":"call","from":"0xb","to":"0xe","calls":[
+			{"type":"call","from":"0xe","to":"0xf","calls":[{"type":"call","from":"0xf","to":"0xg"}]}
+		]}
+	]}`
+
+	tests := []struct {
+		name        string
+		options     traceOptions
+		want        string
+		wantRemoved int
+	}{
+		{
+			name:    "nothing pruned",
+			options: traceOptions{maxDepth: defaultTraceDepth},
+			want: `{"type":"CALL","from":"0xa","to":"0xb","calls":[
+				{"type":"STATICCALL","from":"0xb","to":"0xc","calls":[{"type":"CALL","from":"0xc","to":"0xd"}]},
+				{"type":"CALL","from":"0xb","to":"0xe","calls":[
+					{"type":"CALL","from":"0xe","to":"0xf","calls":[{"type":"CALL","from":"0xf","to":"0xg"}]}
+				]}
+			]}`,
+		},
+		{
+			name:    "depth limit counts cut calls on the frame they were cut from",
+			options: traceOptions{maxDepth: 1},
+			want: `{"type":"CALL","from":"0xa","to":"0xb","calls":[
+				{"type":"STATICCALL","from":"0xb","to":"0xc","collapsedCalls":1},
+				{"type":"CALL","from":"0xb","to":"0xe","collapsedCalls":2}
+			]}`,
+			wantRemoved: 3,
+		},
+		{
+			name:    "static calls hidden with their subcalls",
+			options: traceOptions{maxDepth: defaultTraceDepth, hideStaticCalls: true},
+			want: `{"type":"CALL","from":"0xa","to":"0xb","collapsedCalls":2,"calls":[
+				{"type":"CALL","from":"0xb","to":"0xe","calls":[
+					{"type":"CALL","from":"0xe","to":"0xf","calls":[{"type":"CALL","from":"0xf","to":"0xg"}]}
+				]}
+			]}`,
+			wantRemoved: 2,
+		},
+		{
+			name:    "depth limit and hidden static calls",
+			options: traceOptions{maxDepth: 2, hideStaticCalls: true},
+			want: `{"type":"CALL","from":"0xa","to":"0xb","collapsedCalls":2,"calls":[
+				{"type":"CALL","from":"0xb","to":"0xe","calls":[
+					{"type":"CALL","from":"0xe","to":"0xf","collapsedCalls":1}
+				]}
+			]}`,
+			wantRemoved: 3,
+		},
+		{
+			name:        "root only",
+			options:     traceOptions{maxDepth: 0},
+			want:        `{"type":"CALL","from":"0xa","to":"0xb","collapsedCalls":5}`,
+			wantRemoved: 5,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var frame gethCallFrame
+			if err := json.Unmarshal([]byte(trace), &frame); err != nil {
+				t.Fatalf("invalid test trace: %v", err)
+			}
+			root := fromGethFrame(frame)
+
+			var summary traceSummary
+			summarizeTrace(root, 0, &summary)
+			if summary.TotalCalls != 6 || summary.MaxDepth != 3 {
+				t.Fatalf("summary = %+v, want 6 calls 3 deep", summary)
+			}
+
+			removed := pruneTrace(root, 0, tt.options)
+			if removed != tt.wantRemoved {
+				t.Errorf("pruneTrace() removed %d, want %d", removed, tt.wantRemoved)
+			}
+			if removed != summary.TotalCalls-countCalls(root) {
+				t.Errorf("pruneTrace() removed %d, but %d calls remain of %d", removed, countCalls(root), summary.TotalCalls)
+			}
+			got, _ := json.Marshal(root)
+			if !jsonEqual(t, string(got), tt.want) {
+				t.Errorf("got %s, want %s", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,167 @@
+package mcp
+
+import (
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"strings"
+
+	"github.com/huahuayu/etherscan-mcp-server/internal/etherscan"
+	"github.com/huahuayu/etherscan-mcp-server/internal/rpc"
+	"github.com/huahuayu/etherscan-mcp-server/internal/units"
+)
+
+// transferTopic is keccak256("Transfer(address,address,uint256)"), shared by ERC20 and ERC721
+const transferTopic = "0xddf252ad1be2c89b69c2b068fc378daa952ba7f163c4a11628f55a4df523b3ef"
+
+// logEntry is a log emitted by a transaction
+type logEntry struct {
+	Address  string   `json:"address"`
+	Topics   []string `json:"topics"`
+	Data     string   `json:"data"`
+	LogIndex string   `json:"logIndex"`
+}
+
+// transactionReceipt is the subset of a transaction receipt used to analyse a transaction
+type transactionReceipt struct {
+	Status            string     `json:"status"`
+	Root              string     `json:"root"` // pre-Byzantium receipts have a state root instead of a status
+	From              string     `json:"from"`
+	To                string     `json:"to"`
+	ContractAddress   string     `json:"contractAddress"`
+	BlockNumber       string     `json:"blockNumber"`
+	GasUsed           string     `json:"gasUsed"`
+	EffectiveGasPrice string     `json:"effectiveGasPrice"`
+	L1Fee             string     `json:"l1Fee"` // L1 data fee on OP-stack chains
+	Logs              []logEntry `json:"logs"`
+}
+
+// transactionInfo is the subset of eth_getTransactionByHash used to analyse a transaction
+type transactionInfo struct {
+	Hash        string `json:"hash"`
+	From        string `json:"from"`
+	To          string `json:"to"`
+	Value       string `json:"value"`
+	Input       string `json:"input"`
+	Gas         string `json:"gas"`
+	Nonce       string `json:"nonce"`
+	BlockNumber string `json:"blockNumber"`
+}
+
+// tokenTransfer is a Transfer event; Amount is set for ERC20 and TokenID for ERC721
+type tokenTransfer struct {
+	Token   string
+	From    string
+	To      string
+	Amount  *big.Int
+	TokenID *big.Int
+}
+
+// getTransaction gets and parses a transaction from the chain's backend
+func getTransaction(client etherscan.Explorer, rpcClient *rpc.Client, chainID, txHash string) (*transactionInfo, error) {
+	result, _, err := fetch(chainID,
+		func() (json.RawMessage, error) { return client.GetTransactionByHash(chainID, txHash) },
+		func() (json.RawMessage, error) { return rpcClient.GetTransactionByHash(chainID, txHash) })
+	if err != nil {
+		return nil, err
+	}
+
+	if string(result) == "null" || len(result) == 0 {
+		return nil, fmt.Errorf("transaction %s not found", txHash)
+	}
+
+	var tx transactionInfo
+	if err := json.Unmarshal(result, &tx); err != nil {
+		return nil, fmt.Errorf("failed to parse transaction: %w", err)
+	}
+	return &tx, nil
+}
+
+// getReceipt gets and parses a transaction receipt from the chain's backend
+func getReceipt(client etherscan.Explorer, rpcClient *rpc.Client, chainID, txHash string) (*transactionReceipt, error) {
+	result, _, err := fetch(chainID,
+		func() (json.RawMessage, error) { return client.GetTransactionReceipt(chainID, txHash) },
+		func() (json.RawMessage, error) { return rpcClient.GetTransactionReceipt(chainID, txHash) })
+	if err != nil {
+		return nil, err
+	}
+
+	if string(result) == "null" || len(result) == 0 {
+		return nil, fmt.Errorf("transaction %s not found or still pending", txHash)
+	}
+
+	var receipt transactionReceipt
+	if err := json.Unmarshal(result, &receipt); err != nil {
+		return nil, fmt.Errorf("failed to parse transaction receipt: %w", err)
+	}
+	return &receipt, nil
+}
+
+// tokenTransfers extracts the ERC20 and ERC721 Transfer events from logs
+func tokenTransfers(logs []logEntry) []tokenTransfer {
+	var transfers []tokenTransfer
+	for _, entry := range logs {
+		if len(entry.Topics) < 3 || strings.ToLower(entry.Topics[0]) != transferTopic {
+			continue
+		}
+		from, okFrom := topicAddress(entry.Topics[1])
+		to, okTo := topicAddress(entry.Topics[2])
+		if !okFrom || !okTo {
+			continue
+		}
+
+		transfer := tokenTransfer{Token: strings.ToLower(entry.Address), From: from, To: to}
+		switch len(entry.Topics) {
+		case 3:
+			// ERC20: the amount is the only data word
+			amount, ok := units.ParseBigInt(entry.Data)
+			if !ok {
+				continue
+			}
+			transfer.Amount = amount
+		case 4:
+			// ERC721: the token ID is indexed
+			tokenID, ok := units.ParseBigInt(entry.Topics[3])
+			if !ok {
+				continue
+			}
+			transfer.TokenID = tokenID
+		default:
+			continue
+		}
+		transfers = append(transfers, transfer)
+	}
+	return transfers
+}
+
+// topicAddress extracts an address from an indexed address topic
+func topicAddress(topic string) (string, bool) {
+	raw := strings.TrimPrefix(strings.ToLower(topic), "0x")
+	if len(raw) != 64 || strings.Trim(raw[:24], "0") != "" {
+		return "", false
+	}
+	return "0x" + raw[24:], true
+}
+
+// erc20BalanceChanges nets ERC20 transfers into per-holder, per-token balance changes
+func erc20BalanceChanges(transfers []tokenTransfer) map[string]map[string]*big.Int {
+	changes := make(map[string]map[string]*big.Int)
+	add := func(holder, token string, amount *big.Int) {
+		if changes[holder] == nil {
+			changes[holder] = make(map[string]*big.Int)
+		}
+		if changes[holder][token] == nil {
+			changes[holder][token] = new(big.Int)
+		}
+		changes[holder][token].Add(changes[holder][token], amount)
+	}
+
+	for _, transfer := range transfers {
+		if transfer.Amount == nil {
+			continue
+		}
+		add(transfer.From, transfer.Token, new(big.Int).Neg(transfer.Amount))
+		add(transfer.To, transfer.Token, transfer.Amount)
+	}
+	return changes
+}
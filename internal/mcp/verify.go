@@ -0,0 +1,101 @@
+package mcp
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"strconv"
+	"time"
+
+	"github.com/huahuayu/etherscan-mcp-server/internal/etherscan"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+)
+
+const (
+	// verificationPollInterval is the delay between verification status checks
+	verificationPollInterval = 5 * time.Second
+	// defaultVerificationTimeout bounds how long a tool waits for a verification to finish
+	defaultVerificationTimeout = 120 * time.Second
+)
+
+// verificationCheck gets the current state of a verification submission
+type verificationCheck func() (*etherscan.VerificationStatus, error)
+
+// pollVerification checks a verification submission until it reaches a final state or times out,
+// reporting each check as an MCP progress notification when the caller asked for progress.
+// A pending status is returned on timeout so the caller can resume with checkVerificationStatus.
+func pollVerification(ctx context.Context, request mcp.CallToolRequest, timeout time.Duration, check verificationCheck) (*etherscan.VerificationStatus, error) {
+	deadline := time.Now().Add(timeout)
+	for attempt := 1; ; attempt++ {
+		// Etherscan needs a moment before a fresh GUID is queryable
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		case <-time.After(verificationPollInterval):
+		}
+
+		status, err := check()
+		if err != nil {
+			return nil, err
+		}
+
+		sendProgress(ctx, request, attempt, status.Message)
+
+		if status.Done() || time.Now().Add(verificationPollInterval).After(deadline) {
+			return status, nil
+		}
+	}
+}
+
+// sendProgress sends a notifications/progress message if the request carries a progress token
+func sendProgress(ctx context.Context, request mcp.CallToolRequest, progress int, message string) {
+	if request.Params.Meta == nil || request.Params.Meta.ProgressToken == nil {
+		return
+	}
+
+	srv := server.ServerFromContext(ctx)
+	if srv == nil {
+		return
+	}
+
+	err := srv.SendNotificationToClient(ctx, "notifications/progress", map[string]any{
+		"progressToken": request.Params.Meta.ProgressToken,
+		"progress":      progress,
+		"message":       message,
+	})
+	if err != nil {
+		log.Printf("Failed to send progress notification: %v", err)
+	}
+}
+
+// verificationTimeout reads the optional timeoutSeconds argument
+func verificationTimeout(arguments map[string]interface{}) (time.Duration, error) {
+	value, ok := arguments["timeoutSeconds"].(string)
+	if !ok || value == "" {
+		return defaultVerificationTimeout, nil
+	}
+
+	seconds, err := strconv.Atoi(value)
+	if err != nil || seconds <= 0 {
+		return 0, fmt.Errorf("timeoutSeconds must be a positive integer")
+	}
+
+	return time.Duration(seconds) * time.Second, nil
+}
+
+// verificationResult builds the tool response for a verification submission
+func verificationResult(chainID, address string, status *etherscan.VerificationStatus) map[string]interface{} {
+	result := map[string]interface{}{
+		"chainID":         chainID,
+		"guid":            status.GUID,
+		"status":          status.State,
+		"success":         status.State == etherscan.VerificationPassed || status.State == etherscan.VerificationAlready,
+		"message":         status.Message,
+		"contractAddress": address,
+	}
+	if status.State == etherscan.VerificationPending {
+		result["hint"] = "Verification is still running; call checkVerificationStatus with this guid to follow up"
+	}
+	return result
+}
@@ -3,28 +3,71 @@ package rpc
 import (
 	"bytes"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
 	"math/big"
 	"net/http"
 	"strconv"
 	"strings"
+	"sync"
 	"time"
+
+	"github.com/huahuayu/etherscan-mcp-server/internal/chains"
 )
 
-// Pre-configured free RPC endpoints from LlamaRPC
-var chainRPCURLs = map[string]string{
-	"56":    "https://binance.llamarpc.com",          // BSC
-	"8453":  "https://base.llamarpc.com",             // Base
-	"43114": "https://api.avax.network/ext/bc/C/rpc", // Avalanche C-Chain
-}
+// chainRPCURLs holds the configured RPC endpoints; chains without one use the public endpoint
+// from the chain registry, if any
+var chainRPCURLs = map[string]string{}
+
+var chainRPCURLsMu sync.RWMutex
 
 // IsRPCFallbackChain checks if a chain has RPC fallback support
 func IsRPCFallbackChain(chainID string) bool {
-	_, ok := chainRPCURLs[chainID]
+	_, ok := endpoint(chainID)
 	return ok
 }
 
+// SetEndpoint configures the RPC endpoint used for a chain, replacing any pre-configured one
+func SetEndpoint(chainID, rpcURL string) {
+	chainRPCURLsMu.Lock()
+	defer chainRPCURLsMu.Unlock()
+	chainRPCURLs[chainID] = rpcURL
+}
+
+// ParseEndpoints parses a comma-separated list of chainID=url pairs
+// (e.g. "1=https://eth.llamarpc.com,10=https://mainnet.optimism.io")
+func ParseEndpoints(spec string) (map[string]string, error) {
+	endpoints := make(map[string]string)
+	for _, entry := range strings.Split(spec, ",") {
+		entry = strings.TrimSpace(entry)
+		if entry == "" {
+			continue
+		}
+		chainID, rpcURL, ok := strings.Cut(entry, "=")
+		if !ok || strings.TrimSpace(chainID) == "" || strings.TrimSpace(rpcURL) == "" {
+			return nil, fmt.Errorf("invalid RPC endpoint %q, expected chainID=url", entry)
+		}
+		endpoints[strings.TrimSpace(chainID)] = strings.TrimSpace(rpcURL)
+	}
+	return endpoints, nil
+}
+
+// endpoint returns the RPC endpoint configured for a chain
+func endpoint(chainID string) (string, bool) {
+	chainRPCURLsMu.RLock()
+	rpcURL, ok := chainRPCURLs[chainID]
+	chainRPCURLsMu.RUnlock()
+	if ok {
+		return rpcURL, true
+	}
+
+	if chain, ok := chains.Get(chainID); ok && chain.RPCURL != "" {
+		return chain.RPCURL, true
+	}
+	return "", false
+}
+
 // Client is a JSON-RPC client for direct RPC calls
 type Client struct {
 	httpClient *http.Client
@@ -48,8 +91,29 @@ type jsonRPCResponse struct {
 
 // jsonRPCError represents a JSON-RPC error
 type jsonRPCError struct {
-	Code    int    `json:"code"`
-	Message string `json:"message"`
+	Code    int             `json:"code"`
+	Message string          `json:"message"`
+	Data    json.RawMessage `json:"data,omitempty"`
+}
+
+// Error is an error returned by the RPC node. Data carries the revert data of failed calls, if any.
+type Error struct {
+	Code    int
+	Message string
+	Data    string
+}
+
+func (e *Error) Error() string {
+	return fmt.Sprintf("RPC error %d: %s", e.Code, e.Message)
+}
+
+// RevertData extracts the hex revert data from an RPC error, if the node returned any
+func RevertData(err error) (string, bool) {
+	var rpcErr *Error
+	if !errors.As(err, &rpcErr) || !strings.HasPrefix(rpcErr.Data, "0x") {
+		return "", false
+	}
+	return rpcErr.Data, true
 }
 
 // NewClient creates a new RPC client
@@ -63,7 +127,7 @@ func NewClient() *Client {
 
 // call performs a JSON-RPC call to the appropriate chain RPC endpoint
 func (c *Client) call(chainID, method string, params []interface{}) (json.RawMessage, error) {
-	rpcURL, ok := chainRPCURLs[chainID]
+	rpcURL, ok := endpoint(chainID)
 	if !ok {
 		return nil, fmt.Errorf("no RPC endpoint configured for chain %s", chainID)
 	}
@@ -103,7 +167,20 @@ func (c *Client) call(chainID, method string, params []interface{}) (json.RawMes
 	}
 
 	if rpcResp.Error != nil {
-		return nil, fmt.Errorf("RPC error %d: %s", rpcResp.Error.Code, rpcResp.Error.Message)
+		rpcErr := &Error{Code: rpcResp.Error.Code, Message: rpcResp.Error.Message}
+		// Nodes return revert data as a hex string; some wrap it in an object
+		var data string
+		if err := json.Unmarshal(rpcResp.Error.Data, &data); err == nil {
+			rpcErr.Data = data
+		} else {
+			var wrapped struct {
+				Data string `json:"data"`
+			}
+			if err := json.Unmarshal(rpcResp.Error.Data, &wrapped); err == nil {
+				rpcErr.Data = wrapped.Data
+			}
+		}
+		return nil, rpcErr
 	}
 
 	return rpcResp.Result, nil
@@ -244,16 +321,52 @@ func (c *Client) GetTokenDetails(chainID, contractAddress string) (json.RawMessa
 	return []byte(responseJSON), nil
 }
 
+// GetBlockByNumber returns a block by number or tag, with full transactions when fullTx is true
+func (c *Client) GetBlockByNumber(chainID, blockNumber string, fullTx bool) (json.RawMessage, error) {
+	return c.call(chainID, "eth_getBlockByNumber", []interface{}{toHexTag(blockNumber), fullTx})
+}
+
+// GetBlockByHash returns a block by hash, with full transactions when fullTx is true
+func (c *Client) GetBlockByHash(chainID, blockHash string, fullTx bool) (json.RawMessage, error) {
+	return c.call(chainID, "eth_getBlockByHash", []interface{}{blockHash, fullTx})
+}
+
 // GetTransactionByHash returns transaction details by hash
 func (c *Client) GetTransactionByHash(chainID, txHash string) (json.RawMessage, error) {
 	return c.call(chainID, "eth_getTransactionByHash", []interface{}{txHash})
 }
 
+// GetTransactionByBlockNumberAndIndex returns a transaction by block number or tag and index
+func (c *Client) GetTransactionByBlockNumberAndIndex(chainID, blockNumber, index string) (json.RawMessage, error) {
+	return c.call(chainID, "eth_getTransactionByBlockNumberAndIndex", []interface{}{toHexTag(blockNumber), toHexTag(index)})
+}
+
 // GetTransactionReceipt returns the transaction receipt
 func (c *Client) GetTransactionReceipt(chainID, txHash string) (json.RawMessage, error) {
 	return c.call(chainID, "eth_getTransactionReceipt", []interface{}{txHash})
 }
 
+// GetLogs returns the logs in a block range, optionally emitted by an address. topics holds one
+// entry per topic position, with nil matching any topic.
+func (c *Client) GetLogs(chainID, fromBlock, toBlock, address string, topics []interface{}) (json.RawMessage, error) {
+	filter := map[string]interface{}{
+		"fromBlock": toHexTag(fromBlock),
+		"toBlock":   toHexTag(toBlock),
+	}
+	if address != "" {
+		filter["address"] = address
+	}
+	if len(topics) > 0 {
+		filter["topics"] = topics
+	}
+	return c.call(chainID, "eth_getLogs", []interface{}{filter})
+}
+
+// SendRawTransaction broadcasts a signed transaction and returns its hash
+func (c *Client) SendRawTransaction(chainID, signedTx string) (string, error) {
+	return c.callString(chainID, "eth_sendRawTransaction", []interface{}{signedTx})
+}
+
 // GetTransactionCount returns the number of transactions from an address
 func (c *Client) GetTransactionCount(chainID, address, tag string) (json.RawMessage, error) {
 	if tag == "" {
@@ -262,13 +375,107 @@ func (c *Client) GetTransactionCount(chainID, address, tag string) (json.RawMess
 	return c.call(chainID, "eth_getTransactionCount", []interface{}{address, tag})
 }
 
+// GetCode returns the deployed bytecode at an address
+func (c *Client) GetCode(chainID, address, tag string) (string, error) {
+	if tag == "" {
+		tag = "latest"
+	}
+	return c.callString(chainID, "eth_getCode", []interface{}{address, toHexTag(tag)})
+}
+
+// GetStorageAt returns the 32-byte value stored at a storage slot of an address
+func (c *Client) GetStorageAt(chainID, address, position, tag string) (string, error) {
+	if tag == "" {
+		tag = "latest"
+	}
+	return c.callString(chainID, "eth_getStorageAt", []interface{}{address, position, toHexTag(tag)})
+}
+
+// GasPrice returns the current gas price in wei as a hex quantity
+func (c *Client) GasPrice(chainID string) (string, error) {
+	return c.callString(chainID, "eth_gasPrice", []interface{}{})
+}
+
+// MaxPriorityFeePerGas returns the suggested EIP-1559 priority fee in wei as a hex quantity
+func (c *Client) MaxPriorityFeePerGas(chainID string) (string, error) {
+	return c.callString(chainID, "eth_maxPriorityFeePerGas", []interface{}{})
+}
+
+// FeeHistory returns base fees, gas used ratios and priority fee percentiles for a range of recent blocks
+func (c *Client) FeeHistory(chainID string, blockCount uint64, newestBlock string, rewardPercentiles []float64) (json.RawMessage, error) {
+	if rewardPercentiles == nil {
+		rewardPercentiles = []float64{}
+	}
+	return c.call(chainID, "eth_feeHistory", []interface{}{fmt.Sprintf("0x%x", blockCount), toHexTag(newestBlock), rewardPercentiles})
+}
+
+// EstimateGas estimates the gas needed for a call object (to, from, data, value as hex quantities)
+func (c *Client) EstimateGas(chainID string, callObject map[string]string) (string, error) {
+	return c.callString(chainID, "eth_estimateGas", []interface{}{callObject})
+}
+
+// DebugTraceTransaction replays a transaction with debug_traceTransaction using the given tracer options
+// (e.g. {"tracer": "callTracer"}). Only available on nodes that expose the debug namespace.
+func (c *Client) DebugTraceTransaction(chainID, txHash string, options map[string]interface{}) (json.RawMessage, error) {
+	return c.call(chainID, "debug_traceTransaction", []interface{}{txHash, options})
+}
+
+// TraceTransaction gets the flat call traces of a transaction with trace_transaction (Erigon, Nethermind, Reth)
+func (c *Client) TraceTransaction(chainID, txHash string) (json.RawMessage, error) {
+	return c.call(chainID, "trace_transaction", []interface{}{txHash})
+}
+
+// TraceReplayTransaction replays a transaction with trace_replayTransaction for the given trace types (e.g. "stateDiff")
+func (c *Client) TraceReplayTransaction(chainID, txHash string, traceTypes []string) (json.RawMessage, error) {
+	return c.call(chainID, "trace_replayTransaction", []interface{}{txHash, traceTypes})
+}
+
+// Call executes eth_call for a call object at a block, with optional state overrides
+// (address → balance, nonce, code, state or stateDiff). Returns the hex return data.
+func (c *Client) Call(chainID string, callObject map[string]string, blockNumber string, overrides map[string]interface{}) (string, error) {
+	params := []interface{}{callObject, toHexTag(blockNumber)}
+	if len(overrides) > 0 {
+		params = append(params, overrides)
+	}
+	return c.callString(chainID, "eth_call", params)
+}
+
+// EstimateGasAt estimates the gas of a call object at a block, with optional state overrides
+func (c *Client) EstimateGasAt(chainID string, callObject map[string]string, blockNumber string, overrides map[string]interface{}) (string, error) {
+	params := []interface{}{callObject, toHexTag(blockNumber)}
+	if len(overrides) > 0 {
+		params = append(params, overrides)
+	}
+	return c.callString(chainID, "eth_estimateGas", params)
+}
+
+// callString performs a JSON-RPC call whose result is a single string
+func (c *Client) callString(chainID, method string, params []interface{}) (string, error) {
+	result, err := c.call(chainID, method, params)
+	if err != nil {
+		return "", err
+	}
+
+	var value string
+	if err := json.Unmarshal(result, &value); err != nil {
+		return "", fmt.Errorf("failed to parse %s result: %w", method, err)
+	}
+
+	return value, nil
+}
+
 // EthCall performs a read-only contract call
 func (c *Client) EthCall(chainID, to, data string) (json.RawMessage, error) {
+	return c.EthCallAt(chainID, to, data, "latest")
+}
+
+// EthCallAt performs a read-only contract call against the state at a block number or tag
+func (c *Client) EthCallAt(chainID, to, data, blockNumber string) (json.RawMessage, error) {
 	callData := map[string]string{
 		"to":   to,
 		"data": data,
 	}
-	return c.call(chainID, "eth_call", []interface{}{callData, "latest"})
+	return c.call(chainID, "eth_call", []interface{}{callData, toHexTag(blockNumber)})
 }
 
 // decodeAbiString decodes an ABI-encoded string from a hex representation
@@ -313,6 +520,14 @@ func decodeAbiString(hexData string) string {
 	return string(bytes)
 }
 
+// toHexTag converts a decimal number to a hex quantity, leaving block tags and hex values unchanged
+func toHexTag(value string) string {
+	if num, err := strconv.ParseUint(value, 10, 64); err == nil {
+		return fmt.Sprintf("0x%x", num)
+	}
+	return value
+}
+
 // hexToBytes converts a hex string to bytes
 func hexToBytes(hexStr string) ([]byte, error) {
 	length := len(hexStr)
@@ -0,0 +1,59 @@
+package units
+
+import (
+	"math/big"
+	"strings"
+)
+
+// ParseBigInt parses a decimal or 0x-prefixed hex integer string
+func ParseBigInt(value string) (*big.Int, bool) {
+	value = strings.TrimSpace(value)
+	if strings.HasPrefix(value, "0x") || strings.HasPrefix(value, "0X") {
+		hexDigits := value[2:]
+		if hexDigits == "" {
+			return new(big.Int), true
+		}
+		return new(big.Int).SetString(hexDigits, 16)
+	}
+	return new(big.Int).SetString(value, 10)
+}
+
+// FormatUnits formats an integer amount as a decimal string with the given number of decimals
+// (e.g. a wei amount with 18 decimals as ether). Trailing zeros of the fraction are trimmed.
+func FormatUnits(amount *big.Int, decimals int) string {
+	if decimals <= 0 {
+		return amount.String()
+	}
+
+	negative := amount.Sign() < 0
+	digits := new(big.Int).Abs(amount).String()
+	if len(digits) <= decimals {
+		digits = strings.Repeat("0", decimals-len(digits)+1) + digits
+	}
+
+	whole := digits[:len(digits)-decimals]
+	fraction := strings.TrimRight(digits[len(digits)-decimals:], "0")
+
+	result := whole
+	if fraction != "" {
+		result += "." + fraction
+	}
+	if negative {
+		result = "-" + result
+	}
+	return result
+}
+
+// Ratio returns numerator/denominator as a float64, or 0 when the denominator is zero
+func Ratio(numerator, denominator *big.Int) float64 {
+	if denominator.Sign() == 0 {
+		return 0
+	}
+	ratio, _ := new(big.Rat).SetFrac(numerator, denominator).Float64()
+	return ratio
+}
+
+// ToHex formats an integer as a 0x-prefixed hex quantity
+func ToHex(value *big.Int) string {
+	return "0x" + value.Text(16)
+}
@@ -0,0 +1,88 @@
+package units
+
+import (
+	"math/big"
+	"testing"
+)
+
+func TestParseBigInt(t *testing.T) {
+	tests := []struct {
+		input  string
+		want   string
+		wantOK bool
+	}{
+		{"0", "0", true},
+		{"1000000000000000000", "1000000000000000000", true},
+		{" 42 ", "42", true},
+		{"-7", "-7", true},
+		{"010", "10", true},
+		{"0x10", "16", true},
+		{"0XfF", "255", true},
+		{"0x", "0", true},
+		{"0b101", "", false},
+		{"1_000", "", false},
+		{"12abc", "", false},
+		{"0xzz", "", false},
+		{"", "", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			got, ok := ParseBigInt(tt.input)
+			if ok != tt.wantOK {
+				t.Fatalf("ParseBigInt(%q) ok = %v, want %v", tt.input, ok, tt.wantOK)
+			}
+			if ok && got.String() != tt.want {
+				t.Errorf("ParseBigInt(%q) = %s, want %s", tt.input, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestFormatUnits(t *testing.T) {
+	tests := []struct {
+		amount   string
+		decimals int
+		want     string
+	}{
+		{"1000000000000000000", 18, "1"},
+		{"1500000000000000000", 18, "1.5"},
+		{"1", 18, "0.000000000000000001"},
+		{"0", 18, "0"},
+		{"123456789", 6, "123.456789"},
+		{"100000000", 6, "100"},
+		{"-2500000", 6, "-2.5"},
+		{"-1", 6, "-0.000001"},
+		{"12345", 0, "12345"},
+		{"12345", -2, "12345"},
+		{"115792089237316195423570985008687907853269984665640564039457584007913129639935", 18,
+			"115792089237316195423570985008687907853269984665640564039457.584007913129639935"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.amount, func(t *testing.T) {
+			amount, ok := new(big.Int).SetString(tt.amount, 10)
+			if !ok {
+				t.Fatalf("invalid test amount %q", tt.amount)
+			}
+			if got := FormatUnits(amount, tt.decimals); got != tt.want {
+				t.Errorf("FormatUnits(%s, %d) = %s, want %s", tt.amount, tt.decimals, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRatioAndToHex(t *testing.T) {
+	if got := Ratio(big.NewInt(1), big.NewInt(4)); got != 0.25 {
+		t.Errorf("Ratio(1, 4) = %v, want 0.25", got)
+	}
+	if got := Ratio(big.NewInt(1), big.NewInt(0)); got != 0 {
+		t.Errorf("Ratio(1, 0) = %v, want 0", got)
+	}
+	if got := ToHex(big.NewInt(255)); got != "0xff" {
+		t.Errorf("ToHex(255) = %s, want 0xff", got)
+	}
+	if got := ToHex(new(big.Int)); got != "0x0" {
+		t.Errorf("ToHex(0) = %s, want 0x0", got)
+	}
+}